@@ -0,0 +1,120 @@
+package samson
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+)
+
+// AccessTokenService service
+type AccessTokenService service
+
+// AccessToken model
+// Token is only returned once, in the response of the create call
+type AccessToken struct {
+	ID              *int       `json:"id,omitempty"`
+	Description     *string    `json:"description,omitempty"`
+	Scopes          *string    `json:"scopes,omitempty"`
+	Token           *string    `json:"token,omitempty"`
+	ResourceOwnerID *int       `json:"resource_owner_id,omitempty"`
+	LastUsedAt      *time.Time `json:"last_used_at,omitempty"`
+	CreatedAt       *time.Time `json:"created_at,omitempty"`
+	RevokedAt       *time.Time `json:"revoked_at,omitempty"`
+}
+
+// Scopes returns the space separated scope list Samson expects
+func Scopes(scopes ...string) *string {
+	return String(strings.Join(scopes, " "))
+}
+
+// ScopeList returns the scopes of the token
+func (t *AccessToken) ScopeList() []string {
+	if t.Scopes == nil {
+		return []string{}
+	}
+
+	return strings.Fields(*t.Scopes)
+}
+
+// List returns the access tokens of the current user
+func (service *AccessTokenService) List() ([]*AccessToken, *Call, error) {
+	path := "/access_tokens.json"
+	method := "GET"
+
+	call, err := service.s.NewCall(method, path, nil, nil, nil)
+	if err != nil {
+		return nil, call, err
+	}
+
+	type response struct {
+		AccessTokens []*AccessToken `json:"access_tokens,omitempty"`
+	}
+	var res response
+	err = call.Do(&res)
+	if err != nil {
+		return nil, call, err
+	}
+
+	return res.AccessTokens, call, nil
+}
+
+// Create creates a new access token for the current user
+func (service *AccessTokenService) Create(accessToken *AccessToken) (*AccessToken, *Call, error) {
+	bytesArray, _ := json.Marshal(accessToken)
+
+	path := "/access_tokens.json"
+	method := "POST"
+
+	call, err := service.s.NewCall(method, path, nil, nil, bytes.NewReader(bytesArray))
+	if err != nil {
+		return nil, call, err
+	}
+
+	err = call.Do(&accessToken)
+	if err != nil {
+		return nil, call, err
+	}
+
+	return accessToken, call, nil
+}
+
+// Revoke revokes a single access token
+func (service *AccessTokenService) Revoke(id int) (*Call, error) {
+	path := fmt.Sprintf("/access_tokens/%d.json", id)
+	method := "DELETE"
+
+	call, err := service.s.NewCall(method, path, nil, nil, nil)
+	if err != nil {
+		return call, err
+	}
+
+	return call, call.Do(nil)
+}
+
+// Rotate creates a new access token with the description and scopes of
+// the given one and revokes the old token afterwards
+// The new token is returned even if revoking the old one fails
+func (service *AccessTokenService) Rotate(accessToken *AccessToken) (*AccessToken, *Call, error) {
+	if accessToken.ID == nil {
+		return nil, nil, fmt.Errorf("access token without id can not be rotated")
+	}
+
+	newAccessToken := &AccessToken{
+		Description: accessToken.Description,
+		Scopes:      accessToken.Scopes,
+	}
+
+	newAccessToken, call, err := service.Create(newAccessToken)
+	if err != nil {
+		return nil, call, err
+	}
+
+	call, err = service.Revoke(*accessToken.ID)
+	if err != nil {
+		return newAccessToken, call, err
+	}
+
+	return newAccessToken, call, nil
+}
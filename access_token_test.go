@@ -0,0 +1,244 @@
+package samson
+
+import (
+	"encoding/json"
+	"fmt"
+	"log"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func ExampleAccessTokenService_Create() {
+	client := New("token")
+
+	accessToken := &AccessToken{
+		Description: String("lock bot"),
+		Scopes:      Scopes("default", "locks"),
+	}
+
+	accessToken, _, err := client.AccessTokens.Create(accessToken)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	fmt.Println(*accessToken.Token)
+}
+
+func ExampleAccessTokenService_Rotate() {
+	client := New("token")
+
+	accessTokens, _, err := client.AccessTokens.List()
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	for _, accessToken := range accessTokens {
+		if *accessToken.Description != "lock bot" {
+			continue
+		}
+
+		accessToken, _, err = client.AccessTokens.Rotate(accessToken)
+		if err != nil {
+			log.Fatal(err)
+		}
+
+		fmt.Println(*accessToken.Token)
+	}
+}
+
+func TestAccessTokenScopes(t *testing.T) {
+	assert := assert.New(t)
+
+	assert.Equal("default locks", *Scopes("default", "locks"))
+	assert.Equal([]string{"default", "locks"}, (&AccessToken{Scopes: String("default  locks")}).ScopeList())
+	assert.Equal([]string{}, (&AccessToken{}).ScopeList())
+}
+
+func TestAccessTokenServiceList(t *testing.T) {
+	var err error
+	assert := assert.New(t)
+
+	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal("GET", r.Method)
+		assert.Equal("/access_tokens.json", r.URL.Path)
+		checkHeaders(r, assert)
+
+		w.WriteHeader(200)
+		fmt.Fprintln(w, readTestData("access_tokens.json"))
+	})
+
+	server := httptest.NewServer(handler)
+	defer server.Close()
+
+	client = New(token)
+	client.BaseURL = server.URL
+
+	accessTokens, call, err := client.AccessTokens.List()
+	assert.Nil(err)
+	assert.IsType(&Call{}, call)
+	assert.Equal(2, len(accessTokens))
+	assert.NotNil(accessTokens[0].LastUsedAt)
+	assert.Nil(accessTokens[1].LastUsedAt)
+	assert.Nil(accessTokens[0].Token)
+}
+
+func TestAccessTokenServiceList_fail(t *testing.T) {
+	var err error
+	assert := assert.New(t)
+
+	client = New(token)
+	client.BaseURL = "^http://localhost"
+
+	accessTokens, call, err := client.AccessTokens.List()
+	assert.NotNil(err)
+	assert.IsType(&Call{}, call)
+	assert.Nil(accessTokens)
+}
+
+func TestAccessTokenServiceCreate(t *testing.T) {
+	var err error
+	assert := assert.New(t)
+
+	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal("POST", r.Method)
+		assert.Equal("/access_tokens.json", r.URL.Path)
+		checkHeaders(r, assert)
+
+		var payload map[string]interface{}
+		err := json.NewDecoder(r.Body).Decode(&payload)
+		assert.Nil(err)
+		assert.Equal("lock bot", payload["description"])
+		assert.Equal("default locks", payload["scopes"])
+
+		w.WriteHeader(200)
+		fmt.Fprintln(w, readTestData("access_token_new.json"))
+	})
+
+	server := httptest.NewServer(handler)
+	defer server.Close()
+
+	client = New(token)
+	client.BaseURL = server.URL
+
+	accessToken := &AccessToken{
+		Description: String("lock bot"),
+		Scopes:      Scopes("default", "locks"),
+	}
+
+	accessToken, call, err := client.AccessTokens.Create(accessToken)
+	assert.Nil(err)
+	assert.IsType(&Call{}, call)
+	assert.Equal(3, *accessToken.ID)
+	assert.NotNil(accessToken.Token)
+}
+
+func TestAccessTokenServiceCreate_fail(t *testing.T) {
+	var err error
+	assert := assert.New(t)
+
+	client = New(token)
+	client.BaseURL = "^http://localhost"
+
+	accessToken, call, err := client.AccessTokens.Create(&AccessToken{})
+	assert.NotNil(err)
+	assert.IsType(&Call{}, call)
+	assert.Nil(accessToken)
+}
+
+func TestAccessTokenServiceRevoke(t *testing.T) {
+	var err error
+	assert := assert.New(t)
+
+	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal("DELETE", r.Method)
+		assert.Equal("/access_tokens/2.json", r.URL.Path)
+		checkHeaders(r, assert)
+
+		w.WriteHeader(200)
+		fmt.Fprintln(w, "")
+	})
+
+	server := httptest.NewServer(handler)
+	defer server.Close()
+
+	client = New(token)
+	client.BaseURL = server.URL
+
+	call, err := client.AccessTokens.Revoke(2)
+	assert.Nil(err)
+	assert.IsType(&Call{}, call)
+}
+
+func TestAccessTokenServiceRotate(t *testing.T) {
+	var err error
+	assert := assert.New(t)
+
+	requests := []string{}
+	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests = append(requests, r.Method+" "+r.URL.Path)
+
+		if r.Method == "POST" {
+			var payload map[string]interface{}
+			err := json.NewDecoder(r.Body).Decode(&payload)
+			assert.Nil(err)
+			assert.Equal("lock bot", payload["description"])
+			assert.Equal("default locks", payload["scopes"])
+			assert.Nil(payload["id"])
+
+			fmt.Fprintln(w, readTestData("access_token_new.json"))
+			return
+		}
+
+		fmt.Fprintln(w, "")
+	})
+
+	server := httptest.NewServer(handler)
+	defer server.Close()
+
+	client = New(token)
+	client.BaseURL = server.URL
+
+	accessToken := &AccessToken{
+		ID:          Int(2),
+		Description: String("lock bot"),
+		Scopes:      Scopes("default", "locks"),
+	}
+
+	accessToken, call, err := client.AccessTokens.Rotate(accessToken)
+	assert.Nil(err)
+	assert.IsType(&Call{}, call)
+	assert.Equal(3, *accessToken.ID)
+	assert.Equal([]string{"POST /access_tokens.json", "DELETE /access_tokens/2.json"}, requests)
+}
+
+func TestAccessTokenServiceRotate_fail(t *testing.T) {
+	var err error
+	assert := assert.New(t)
+
+	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == "POST" {
+			fmt.Fprintln(w, readTestData("access_token_new.json"))
+			return
+		}
+
+		w.WriteHeader(404)
+		fmt.Fprintln(w, readTestData("error-notfound.json"))
+	})
+
+	server := httptest.NewServer(handler)
+	defer server.Close()
+
+	client = New(token)
+	client.BaseURL = server.URL
+
+	_, _, err = client.AccessTokens.Rotate(&AccessToken{})
+	assert.NotNil(err)
+
+	accessToken, _, err := client.AccessTokens.Rotate(&AccessToken{ID: Int(2)})
+	assert.NotNil(err)
+	assert.Equal("Not found error", err.Error())
+	assert.Equal(3, *accessToken.ID)
+}
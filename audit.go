@@ -0,0 +1,162 @@
+package samson
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strconv"
+	"time"
+)
+
+// AuditService service
+type AuditService service
+
+// Types of the resources audits are recorded for
+const (
+	AuditableProject     = "Project"
+	AuditableStage       = "Stage"
+	AuditableCommand     = "Command"
+	AuditableEnvironment = "Environment"
+)
+
+// Audit actions
+const (
+	AuditActionCreate  = "create"
+	AuditActionUpdate  = "update"
+	AuditActionDestroy = "destroy"
+)
+
+// Audit model for a recorded change of a resource
+// AuditedChanges holds [before, after] pairs for updates and single values
+// for creates and destroys, use Changes to read them
+type Audit struct {
+	ID             *int                       `json:"id,omitempty"`
+	AuditableType  *string                    `json:"auditable_type,omitempty"`
+	AuditableID    *int                       `json:"auditable_id,omitempty"`
+	Action         *string                    `json:"action,omitempty"`
+	UserID         *int                       `json:"user_id,omitempty"`
+	Version        *int                       `json:"version,omitempty"`
+	Comment        *string                    `json:"comment,omitempty"`
+	AuditedChanges map[string]json.RawMessage `json:"audited_changes,omitempty"`
+	CreatedAt      *time.Time                 `json:"created_at,omitempty"`
+}
+
+// FieldChange is the change of a single field of an audited resource
+// Before is nil for created and After is nil for destroyed resources
+type FieldChange struct {
+	Field  string
+	Before interface{}
+	After  interface{}
+}
+
+// Changes returns the field changes of the audit ordered by field name
+func (a *Audit) Changes() ([]FieldChange, error) {
+	fields := make([]string, 0, len(a.AuditedChanges))
+	for field := range a.AuditedChanges {
+		fields = append(fields, field)
+	}
+	sort.Strings(fields)
+
+	action := ""
+	if a.Action != nil {
+		action = *a.Action
+	}
+
+	changes := make([]FieldChange, 0, len(fields))
+	for _, field := range fields {
+		change := FieldChange{Field: field}
+
+		switch action {
+		case AuditActionCreate:
+			if err := json.Unmarshal(a.AuditedChanges[field], &change.After); err != nil {
+				return nil, err
+			}
+		case AuditActionDestroy:
+			if err := json.Unmarshal(a.AuditedChanges[field], &change.Before); err != nil {
+				return nil, err
+			}
+		default:
+			var pair []interface{}
+			if err := json.Unmarshal(a.AuditedChanges[field], &pair); err != nil || len(pair) != 2 {
+				return nil, fmt.Errorf("audited change of %s is not a [before, after] pair", field)
+			}
+			change.Before, change.After = pair[0], pair[1]
+		}
+
+		changes = append(changes, change)
+	}
+
+	return changes, nil
+}
+
+// AuditListOptions filters the listed audits, zero values are ignored
+type AuditListOptions struct {
+	AuditableType string
+	AuditableID   int
+	UserID        int
+	Action        string
+	From          time.Time
+	To            time.Time
+	Page          int
+}
+
+func (opts *AuditListOptions) queryParams() map[string]string {
+	queryParams := map[string]string{}
+	if opts == nil {
+		return queryParams
+	}
+
+	if opts.AuditableType != "" {
+		queryParams["auditable_type"] = opts.AuditableType
+	}
+	if opts.AuditableID != 0 {
+		queryParams["auditable_id"] = strconv.Itoa(opts.AuditableID)
+	}
+	if opts.UserID != 0 {
+		queryParams["user_id"] = strconv.Itoa(opts.UserID)
+	}
+	if opts.Action != "" {
+		queryParams["action"] = opts.Action
+	}
+	if !opts.From.IsZero() {
+		queryParams["from"] = opts.From.UTC().Format(time.RFC3339)
+	}
+	if !opts.To.IsZero() {
+		queryParams["to"] = opts.To.UTC().Format(time.RFC3339)
+	}
+	if opts.Page != 0 {
+		queryParams["page"] = strconv.Itoa(opts.Page)
+	}
+
+	return queryParams
+}
+
+// List returns the audits across the instance matching the options
+func (service *AuditService) List(opts *AuditListOptions) ([]*Audit, *Call, error) {
+	path := "/audits.json"
+	method := "GET"
+
+	call, err := service.s.NewCall(method, path, opts.queryParams(), nil, nil)
+	if err != nil {
+		return nil, call, err
+	}
+
+	type response struct {
+		Audits []*Audit `json:"audits,omitempty"`
+	}
+	var res response
+	err = call.Do(&res)
+	if err != nil {
+		return nil, call, err
+	}
+
+	return res.Audits, call, nil
+}
+
+// ListForResource returns the audits of a single resource, e.g. a stage
+func (service *AuditService) ListForResource(auditableType string, auditableID int) ([]*Audit, *Call, error) {
+	return service.List(&AuditListOptions{
+		AuditableType: auditableType,
+		AuditableID:   auditableID,
+	})
+}
@@ -0,0 +1,182 @@
+package samson
+
+import (
+	"encoding/json"
+	"fmt"
+	"log"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func ExampleAuditService_ListForResource() {
+	client := New("token")
+
+	audits, _, err := client.Audits.ListForResource(AuditableStage, 1)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	for _, audit := range audits {
+		changes, err := audit.Changes()
+		if err != nil {
+			log.Fatal(err)
+		}
+
+		for _, change := range changes {
+			fmt.Println(*audit.UserID, change.Field, change.Before, "->", change.After)
+		}
+	}
+}
+
+func TestAuditChanges(t *testing.T) {
+	assert := assert.New(t)
+
+	var res struct {
+		Audits []*Audit `json:"audits"`
+	}
+	err := json.Unmarshal([]byte(readTestData("audits.json")), &res)
+	assert.Nil(err)
+
+	changes, err := res.Audits[0].Changes()
+	assert.Nil(err)
+	assert.Equal([]FieldChange{
+		{Field: "confirm", Before: true, After: false},
+		{Field: "script", Before: "bundle exec rake myapp:deploy", After: "bundle exec rake myapp:deploy --force"},
+	}, changes)
+
+	changes, err = res.Audits[1].Changes()
+	assert.Nil(err)
+	assert.Equal([]FieldChange{
+		{Field: "confirm", After: true},
+		{Field: "name", After: "local"},
+	}, changes)
+
+	changes, err = res.Audits[2].Changes()
+	assert.Nil(err)
+	assert.Equal([]FieldChange{
+		{Field: "command", Before: "echo hello"},
+	}, changes)
+}
+
+func TestAuditChanges_fail(t *testing.T) {
+	assert := assert.New(t)
+
+	audit := &Audit{
+		Action:         String(AuditActionUpdate),
+		AuditedChanges: map[string]json.RawMessage{"name": json.RawMessage(`"local"`)},
+	}
+	_, err := audit.Changes()
+	assert.NotNil(err)
+
+	audit.Action = String(AuditActionCreate)
+	audit.AuditedChanges["name"] = json.RawMessage(`{`)
+	_, err = audit.Changes()
+	assert.NotNil(err)
+}
+
+func TestAuditServiceList(t *testing.T) {
+	var err error
+	assert := assert.New(t)
+
+	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal("GET", r.Method)
+		assert.Equal("/audits.json", r.URL.Path)
+		checkHeaders(r, assert)
+
+		query := r.URL.Query()
+		assert.Equal("Stage", query.Get("auditable_type"))
+		assert.Equal("", query.Get("auditable_id"))
+		assert.Equal("2", query.Get("user_id"))
+		assert.Equal("update", query.Get("action"))
+		assert.Equal("2018-03-28T00:00:00Z", query.Get("from"))
+		assert.Equal("2018-03-29T00:00:00Z", query.Get("to"))
+		assert.Equal("2", query.Get("page"))
+
+		w.WriteHeader(200)
+		fmt.Fprintln(w, readTestData("audits.json"))
+	})
+
+	server := httptest.NewServer(handler)
+	defer server.Close()
+
+	client = New(token)
+	client.BaseURL = server.URL
+
+	opts := &AuditListOptions{
+		AuditableType: AuditableStage,
+		UserID:        2,
+		Action:        AuditActionUpdate,
+		From:          time.Date(2018, 3, 28, 0, 0, 0, 0, time.UTC),
+		To:            time.Date(2018, 3, 29, 0, 0, 0, 0, time.UTC),
+		Page:          2,
+	}
+
+	audits, call, err := client.Audits.List(opts)
+	assert.Nil(err)
+	assert.IsType(&Call{}, call)
+	assert.Equal(3, len(audits))
+}
+
+func TestAuditServiceList_nooptions(t *testing.T) {
+	var err error
+	assert := assert.New(t)
+
+	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal("", r.URL.RawQuery)
+
+		w.WriteHeader(200)
+		fmt.Fprintln(w, readTestData("audits.json"))
+	})
+
+	server := httptest.NewServer(handler)
+	defer server.Close()
+
+	client = New(token)
+	client.BaseURL = server.URL
+
+	audits, _, err := client.Audits.List(nil)
+	assert.Nil(err)
+	assert.Equal(3, len(audits))
+}
+
+func TestAuditServiceList_fail(t *testing.T) {
+	var err error
+	assert := assert.New(t)
+
+	client = New(token)
+	client.BaseURL = "^http://localhost"
+
+	audits, call, err := client.Audits.List(nil)
+	assert.NotNil(err)
+	assert.IsType(&Call{}, call)
+	assert.Nil(audits)
+}
+
+func TestAuditServiceListForResource(t *testing.T) {
+	var err error
+	assert := assert.New(t)
+
+	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal("GET", r.Method)
+		assert.Equal("/audits.json", r.URL.Path)
+		assert.Equal("auditable_id=4&auditable_type=Command", r.URL.RawQuery)
+
+		w.WriteHeader(200)
+		fmt.Fprintln(w, readTestData("audits.json"))
+	})
+
+	server := httptest.NewServer(handler)
+	defer server.Close()
+
+	client = New(token)
+	client.BaseURL = server.URL
+
+	audits, call, err := client.Audits.ListForResource(AuditableCommand, 4)
+	assert.Nil(err)
+	assert.IsType(&Call{}, call)
+	assert.Equal(3, len(audits))
+}
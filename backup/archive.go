@@ -0,0 +1,123 @@
+// Package backup exports the projects, stages, commands, environments and
+// deploy groups of a samson instance into a versioned JSON archive and
+// imports them into an empty instance, remapping the ids they reference
+// each other by. Deploy groups the instance already has are matched by
+// permalink.
+//
+// Environment variable groups, kubernetes clusters, secrets and deploy
+// history are not part of the archive, references to them are dropped on
+// import.
+package backup
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"sort"
+	"time"
+
+	samson "github.com/tolgaakyuz/samson-go"
+)
+
+// Version is the archive format version written by Export
+const Version = 1
+
+// Archive is a snapshot of a samson instance
+type Archive struct {
+	Version      int                   `json:"version"`
+	ExportedAt   time.Time             `json:"exported_at"`
+	Environments []*samson.Environment `json:"environments"`
+	Commands     []*samson.Command     `json:"commands"`
+	Projects     []*samson.Project     `json:"projects"`
+	Stages       []*samson.Stage       `json:"stages"`
+	DeployGroups []*samson.DeployGroup `json:"deploy_groups,omitempty"`
+}
+
+// Export snapshots the instance, resources are ordered by id
+func Export(client *samson.Samson) (*Archive, error) {
+	archive := &Archive{
+		Version:    Version,
+		ExportedAt: time.Now().UTC(),
+	}
+
+	var err error
+	archive.Environments, _, err = client.Environments.List()
+	if err != nil {
+		return nil, err
+	}
+	sort.Slice(archive.Environments, func(i, j int) bool {
+		return id(archive.Environments[i].ID) < id(archive.Environments[j].ID)
+	})
+
+	archive.Commands, _, err = client.Commands.List()
+	if err != nil {
+		return nil, err
+	}
+	sort.Slice(archive.Commands, func(i, j int) bool {
+		return id(archive.Commands[i].ID) < id(archive.Commands[j].ID)
+	})
+
+	projects, _, err := client.Projects.List()
+	if err != nil {
+		return nil, err
+	}
+	for _, project := range projects {
+		full, _, err := client.Projects.Get(*project.ID)
+		if err != nil {
+			return nil, err
+		}
+		archive.Projects = append(archive.Projects, full)
+	}
+	sort.Slice(archive.Projects, func(i, j int) bool {
+		return id(archive.Projects[i].ID) < id(archive.Projects[j].ID)
+	})
+
+	archive.Stages, _, err = client.Stages.List()
+	if err != nil {
+		return nil, err
+	}
+	sort.Slice(archive.Stages, func(i, j int) bool {
+		return id(archive.Stages[i].ID) < id(archive.Stages[j].ID)
+	})
+
+	archive.DeployGroups, _, err = client.DeployGroups.List()
+	if err != nil {
+		return nil, err
+	}
+	sort.Slice(archive.DeployGroups, func(i, j int) bool {
+		return id(archive.DeployGroups[i].ID) < id(archive.DeployGroups[j].ID)
+	})
+
+	return archive, nil
+}
+
+// Write encodes the archive as indented JSON
+func (archive *Archive) Write(w io.Writer) error {
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+
+	return encoder.Encode(archive)
+}
+
+// Read decodes an archive, rejecting versions it doesn't know
+func Read(r io.Reader) (*Archive, error) {
+	var archive Archive
+	err := json.NewDecoder(r).Decode(&archive)
+	if err != nil {
+		return nil, err
+	}
+
+	if archive.Version != Version {
+		return nil, fmt.Errorf("backup: unsupported archive version %d", archive.Version)
+	}
+
+	return &archive, nil
+}
+
+func id(i *int) int {
+	if i == nil {
+		return 0
+	}
+
+	return *i
+}
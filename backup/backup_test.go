@@ -0,0 +1,326 @@
+package backup
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	samson "github.com/tolgaakyuz/samson-go"
+	"github.com/tolgaakyuz/samson-go/internal/samsontest"
+)
+
+func seed(f *samsontest.Server) {
+	f.Add("environments", `{"id": 3, "name": "Production", "production": "1"}`)
+	f.Add("projects", `{"id": 7, "name": "Example", "permalink": "example", "build_command_id": 21,
+		"token": "secret", "star_count": 2, "environment_variable_group_ids": [1],
+		"created_at": "2018-03-26T11:52:01Z",
+		"environment_variables_attributes": [
+			{"id": 4, "name": "RAILS_ENV", "value": "production", "scope_type_and_id": "Environment-3"},
+			{"id": 5, "name": "REGION", "value": "eu", "scope_type_and_id": "DeployGroup-1"}
+		]}`)
+	f.Add("commands", `{"id": 20, "command": "./notify.sh"}`)
+	f.Add("commands", `{"id": 21, "command": "docker build .", "project_id": "7"}`)
+	f.Add("commands", `{"id": 22, "command": "bundle exec cap deploy", "project_id": "7"}`)
+	f.Add("stages", `{"id": 30, "name": "Production", "permalink": "production", "project_id": 7,
+		"command_ids": ["22", "20"], "template_stage_id": 31, "deploy_group_ids": [1],
+		"created_at": "2018-03-26T11:52:01Z",
+		"slack_webhooks_attributes": [{"id": 9, "webhook_url": "https://hooks.slack.com/services/T/B/X", "channel": "deploys"}]}`)
+	f.Add("stages", `{"id": 31, "name": "Staging", "permalink": "staging", "project_id": 7,
+		"command_ids": ["22"], "next_stage_ids": [30], "created_at": "2018-03-26T11:52:01Z"}`)
+	f.Add("deploy_groups", `{"id": 1, "name": "EU", "permalink": "eu", "environment_id": 3}`)
+}
+
+func client(f *samsontest.Server) *samson.Samson {
+	server := f.Start()
+
+	client := samson.New("token")
+	client.BaseURL = server.URL
+
+	return client
+}
+
+func TestExport(t *testing.T) {
+	assert := assert.New(t)
+
+	source := samsontest.New()
+	seed(source)
+
+	archive, err := Export(client(source))
+	assert.Nil(err)
+	assert.Equal(Version, archive.Version)
+	assert.False(archive.ExportedAt.IsZero())
+
+	assert.Len(archive.Environments, 1)
+	assert.Len(archive.Commands, 3)
+	assert.Equal(20, *archive.Commands[0].ID)
+	assert.Equal(22, *archive.Commands[2].ID)
+	assert.Len(archive.Projects, 1)
+	assert.Len(archive.Projects[0].EnvironmentVariableAttributes, 2)
+	assert.Len(archive.Stages, 2)
+	assert.Equal("https://hooks.slack.com/services/T/B/X", *archive.Stages[0].SlackWebhookAtrributes[0].WebhookURL)
+	assert.Equal(30, *archive.Stages[1].NextStageIds[0])
+	assert.Len(archive.DeployGroups, 1)
+	assert.Equal("eu", *archive.DeployGroups[0].Permalink)
+
+	var buf bytes.Buffer
+	assert.Nil(archive.Write(&buf))
+	assert.True(strings.HasPrefix(buf.String(), "{\n  \"version\": 1,\n"))
+
+	read, err := Read(&buf)
+	assert.Nil(err)
+	assert.Equal(archive.Version, read.Version)
+	assert.True(archive.ExportedAt.Equal(read.ExportedAt))
+	assert.Len(read.Stages, 2)
+	assert.Equal(31, *read.Stages[0].TemplateStageID)
+}
+
+func TestExport_fail(t *testing.T) {
+	assert := assert.New(t)
+
+	for _, path := range []string{
+		"GET /environments.json",
+		"GET /commands.json",
+		"GET /projects.json",
+		"GET /projects/7.json",
+		"GET /stages.json",
+		"GET /deploy_groups.json",
+	} {
+		source := samsontest.New()
+		seed(source)
+		source.Fail = path
+
+		_, err := Export(client(source))
+		assert.EqualError(err, "invalid", path)
+	}
+}
+
+func TestRead_fail(t *testing.T) {
+	assert := assert.New(t)
+
+	_, err := Read(strings.NewReader(`{"version": 2}`))
+	assert.EqualError(err, "backup: unsupported archive version 2")
+
+	_, err = Read(strings.NewReader(`malformed json`))
+	assert.NotNil(err)
+}
+
+func TestImport(t *testing.T) {
+	assert := assert.New(t)
+
+	source := samsontest.New()
+	seed(source)
+	archive, err := Export(client(source))
+	assert.Nil(err)
+
+	target := samsontest.New()
+	ids, err := Import(client(target), archive)
+	assert.Nil(err)
+	assert.Equal([]string{
+		"GET /projects.json",
+		"POST /environments.json",
+		"GET /deploy_groups.json",
+		"POST /deploy_groups.json",
+		"POST /projects.json",
+		"POST /commands.json",
+		"POST /commands.json",
+		"POST /commands.json",
+		"PUT /projects/103.json",
+		"POST /stages.json",
+		"POST /stages.json",
+		"PUT /stages/107.json",
+		"PUT /stages/108.json",
+	}, target.Requests)
+
+	assert.Equal(map[int]int{3: 101}, ids.Environments)
+	assert.Equal(map[int]int{1: 102}, ids.DeployGroups)
+	assert.Equal(map[int]int{7: 103}, ids.Projects)
+	assert.Equal(map[int]int{20: 104, 21: 105, 22: 106}, ids.Commands)
+	assert.Equal(map[int]int{30: 107, 31: 108}, ids.Stages)
+
+	deployGroup := target.Resources["deploy_groups"][102]
+	assert.Equal("eu", deployGroup["permalink"])
+	assert.Equal(float64(101), deployGroup["environment_id"])
+
+	project := target.Resources["projects"][103]
+	assert.Equal(float64(105), project["build_command_id"])
+	assert.Nil(project["token"])
+	assert.Nil(project["star_count"])
+	assert.Nil(project["environment_variable_group_ids"])
+	variables := project["environment_variables_attributes"].([]interface{})
+	assert.Len(variables, 2)
+	assert.NotEqual(float64(4), variables[0].(map[string]interface{})["id"])
+	assert.Equal("Environment-101", variables[0].(map[string]interface{})["scope_type_and_id"])
+	assert.Equal("DeployGroup-102", variables[1].(map[string]interface{})["scope_type_and_id"])
+
+	assert.Nil(target.Resources["commands"][104]["project_id"])
+	assert.Equal(float64(103), target.Resources["commands"][105]["project_id"])
+
+	production := target.Resources["stages"][107]
+	assert.Equal(float64(103), production["project_id"])
+	assert.Equal([]interface{}{float64(106), float64(104)}, production["command_ids"])
+	assert.Equal(float64(108), production["template_stage_id"])
+	assert.Equal([]interface{}{float64(102)}, production["deploy_group_ids"])
+	assert.Len(production["slack_webhooks_attributes"], 1)
+
+	staging := target.Resources["stages"][108]
+	assert.Equal([]interface{}{float64(107)}, staging["next_stage_ids"])
+}
+
+func TestImport_existingDeployGroup(t *testing.T) {
+	assert := assert.New(t)
+
+	source := samsontest.New()
+	seed(source)
+	archive, err := Export(client(source))
+	assert.Nil(err)
+
+	target := samsontest.New()
+	target.Add("deploy_groups", `{"id": 8, "name": "EU", "permalink": "eu", "environment_id": 2}`)
+	ids, err := Import(client(target), archive)
+	assert.Nil(err)
+	assert.Equal(map[int]int{1: 8}, ids.DeployGroups)
+	assert.Len(target.Resources["deploy_groups"], 1)
+	assert.NotContains(target.Requests, "POST /deploy_groups.json")
+
+	// archives without deploy groups can't resolve references to them
+	archive.DeployGroups = nil
+	_, err = Import(client(samsontest.New()), archive)
+	assert.EqualError(err, "project example: unknown deploy group 1")
+}
+
+func TestImport_notEmpty(t *testing.T) {
+	assert := assert.New(t)
+
+	target := samsontest.New()
+	seed(target)
+
+	_, err := Import(client(target), &Archive{Version: Version})
+	assert.Equal(ErrNotEmpty, err)
+}
+
+func TestImport_fail(t *testing.T) {
+	assert := assert.New(t)
+
+	source := samsontest.New()
+	seed(source)
+	archive, err := Export(client(source))
+	assert.Nil(err)
+
+	failures := map[string]string{
+		"GET /projects.json":       "invalid",
+		"POST /environments.json":  "environment Production: invalid",
+		"GET /deploy_groups.json":  "invalid",
+		"POST /deploy_groups.json": "deploy group eu: invalid",
+		"POST /projects.json":      "project example: invalid",
+		"POST /commands.json":      "command 20: invalid",
+		"PUT /projects/103.json":   "project example: invalid",
+		"POST /stages.json":        "stage production: invalid",
+		"PUT /stages/107.json":     "stage production: invalid",
+	}
+	for path, message := range failures {
+		target := samsontest.New()
+		target.Fail = path
+
+		_, err := Import(client(target), archive)
+		assert.EqualError(err, message, path)
+	}
+
+	dangling := &Archive{
+		Version:  Version,
+		Commands: []*samson.Command{{ID: samson.Int(1), Command: samson.String("ls"), ProjectID: samson.Int(9)}},
+	}
+	_, err = Import(client(samsontest.New()), dangling)
+	assert.EqualError(err, "command 1: unknown project 9")
+
+	dangling = &Archive{
+		Version: Version,
+		Stages:  []*samson.Stage{{ID: samson.Int(1), ProjectID: samson.Int(9)}},
+	}
+	_, err = Import(client(samsontest.New()), dangling)
+	assert.EqualError(err, "stage 1: unknown project 9")
+
+	dangling = &Archive{
+		Version: Version,
+		Projects: []*samson.Project{{
+			ID:        samson.Int(1),
+			Permalink: samson.String("example"),
+			EnvironmentVariableAttributes: []*samson.EnvironmentVariable{
+				{Name: samson.String("A"), ScopeTypeAndID: samson.String("Environment-9")},
+			},
+		}},
+	}
+	_, err = Import(client(samsontest.New()), dangling)
+	assert.EqualError(err, "project example: unknown environment 9")
+}
+
+func TestRestore(t *testing.T) {
+	assert := assert.New(t)
+
+	archive := &Archive{
+		Version: Version,
+		Projects: []*samson.Project{{
+			ID:        samson.Int(1),
+			Permalink: samson.String("example"),
+			EnvironmentVariableAttributes: []*samson.EnvironmentVariable{
+				{Name: samson.String("A"), ScopeTypeAndID: samson.String("DeployGroup-2")},
+				{Name: samson.String("B"), ScopeTypeAndID: samson.String("")},
+			},
+		}},
+		Stages: []*samson.Stage{{
+			ID:             samson.Int(5),
+			ProjectID:      samson.Int(1),
+			CommandIds:     []*int{samson.Int(3)},
+			DeployGroupIds: []*int{samson.Int(2)},
+		}},
+	}
+
+	target := samsontest.New()
+	ids := NewIDMap()
+	ids.Commands[3] = 30
+	ids.DeployGroups = map[int]int{2: 20}
+
+	err := Restore(client(target), archive, ids)
+	assert.Nil(err)
+	assert.Equal(map[int]int{1: 101}, ids.Projects)
+	assert.Equal(map[int]int{5: 102}, ids.Stages)
+
+	variables := target.Resources["projects"][101]["environment_variables_attributes"].([]interface{})
+	assert.Equal("DeployGroup-20", variables[0].(map[string]interface{})["scope_type_and_id"])
+	assert.Equal("", variables[1].(map[string]interface{})["scope_type_and_id"])
+
+	stage := target.Resources["stages"][102]
+	assert.Equal([]interface{}{float64(30)}, stage["command_ids"])
+	assert.Equal([]interface{}{float64(20)}, stage["deploy_group_ids"])
+
+	ids = NewIDMap()
+	err = Restore(client(samsontest.New()), archive, ids)
+	assert.EqualError(err, "project example: unknown deploy group 2")
+}
+
+func ExampleExport() {
+	client := samson.New("token")
+
+	archive, err := Export(client)
+	if err != nil {
+		panic(err)
+	}
+
+	var buf bytes.Buffer
+	archive.Write(&buf)
+}
+
+func ExampleImport() {
+	archive, err := Read(strings.NewReader(`{"version": 1}`))
+	if err != nil {
+		panic(err)
+	}
+
+	client := samson.New("token")
+	ids, err := Import(client, archive)
+	if err != nil {
+		panic(err)
+	}
+
+	_ = ids.Projects
+}
@@ -0,0 +1,318 @@
+package backup
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+
+	samson "github.com/tolgaakyuz/samson-go"
+)
+
+// ErrNotEmpty is returned when importing into an instance with projects
+var ErrNotEmpty = errors.New("backup: target instance already has projects")
+
+// IDMap maps archived ids to the ids of the imported resources
+type IDMap struct {
+	Environments map[int]int
+	DeployGroups map[int]int
+	Projects     map[int]int
+	Commands     map[int]int
+	Stages       map[int]int
+}
+
+// NewIDMap returns an empty IDMap
+func NewIDMap() *IDMap {
+	return &IDMap{
+		Environments: map[int]int{},
+		DeployGroups: map[int]int{},
+		Projects:     map[int]int{},
+		Commands:     map[int]int{},
+		Stages:       map[int]int{},
+	}
+}
+
+// Import recreates the archive on an empty instance
+func Import(client *samson.Samson, archive *Archive) (*IDMap, error) {
+	projects, _, err := client.Projects.List()
+	if err != nil {
+		return nil, err
+	}
+	if len(projects) > 0 {
+		return nil, ErrNotEmpty
+	}
+
+	ids := NewIDMap()
+	err = Restore(client, archive, ids)
+
+	return ids, err
+}
+
+// Restore creates the archived resources, references to resources not in
+// the archive are resolved through ids and created ones are added to it
+// Deploy groups not in ids are matched with the target's by permalink and
+// created when missing. Projects are created before their commands and stages before their
+// next and template stages are linked, so references are remapped
+// whatever order the ids had
+func Restore(client *samson.Samson, archive *Archive, ids *IDMap) error {
+	for _, environment := range archive.Environments {
+		payload := *environment
+		payload.ID = nil
+		created, _, err := client.Environments.Upsert(&payload)
+		if err != nil {
+			return fmt.Errorf("environment %s: %s", str(environment.Name), err)
+		}
+		ids.Environments[id(environment.ID)] = *created.ID
+	}
+
+	if err := restoreDeployGroups(client, archive, ids); err != nil {
+		return err
+	}
+
+	// build commands belong to their project, linked once both exist
+	createdProjects := map[int]*samson.Project{}
+	for _, project := range archive.Projects {
+		payload := *project
+		payload.ID = nil
+		payload.CreatedAt = nil
+		payload.UpdatedAt = nil
+		payload.Token = nil
+		payload.StarCount = nil
+		payload.LastDeployedAt = nil
+		payload.LastDeployedBy = nil
+		payload.LastDeployURL = nil
+		payload.BuildCommandID = nil
+		payload.EnvironmentVariableGroupIds = nil
+		payload.EnvironmentVariableAttributes = nil
+		for _, variable := range project.EnvironmentVariableAttributes {
+			v := *variable
+			v.ID = nil
+			v.Destroy = nil
+			scope, err := ids.remapScope(str(variable.ScopeTypeAndID))
+			if err != nil {
+				return fmt.Errorf("project %s: %s", str(project.Permalink), err)
+			}
+			if variable.ScopeTypeAndID != nil {
+				v.ScopeTypeAndID = samson.String(scope)
+			}
+			payload.EnvironmentVariableAttributes = append(payload.EnvironmentVariableAttributes, &v)
+		}
+
+		created, _, err := client.Projects.Upsert(&payload)
+		if err != nil {
+			return fmt.Errorf("project %s: %s", str(project.Permalink), err)
+		}
+		ids.Projects[id(project.ID)] = *created.ID
+		createdProjects[id(project.ID)] = created
+	}
+
+	var err error
+	for _, command := range archive.Commands {
+		payload := *command
+		payload.ID = nil
+		if command.ProjectID != nil {
+			payload.ProjectID, err = remap(ids.Projects, *command.ProjectID, "project")
+			if err != nil {
+				return fmt.Errorf("command %d: %s", id(command.ID), err)
+			}
+		}
+
+		created, _, err := client.Commands.Upsert(&payload)
+		if err != nil {
+			return fmt.Errorf("command %d: %s", id(command.ID), err)
+		}
+		ids.Commands[id(command.ID)] = *created.ID
+	}
+
+	for _, project := range archive.Projects {
+		if project.BuildCommandID == nil {
+			continue
+		}
+
+		created := createdProjects[id(project.ID)]
+		payload := &samson.Project{ID: created.ID, CreatedAt: created.CreatedAt}
+		payload.BuildCommandID, err = remap(ids.Commands, *project.BuildCommandID, "command")
+		if err == nil {
+			_, _, err = client.Projects.Upsert(payload)
+		}
+		if err != nil {
+			return fmt.Errorf("project %s: %s", str(project.Permalink), err)
+		}
+	}
+
+	createdStages := map[int]*samson.Stage{}
+	for _, stage := range archive.Stages {
+		payload := *stage
+		payload.ID = nil
+		payload.CreatedAt = nil
+		payload.UpdatedAt = nil
+		payload.DeleteAt = nil
+		payload.DeployGroupIds = nil
+		payload.NextStageIds = nil
+		payload.TemplateStageID = nil
+		payload.SlackWebhookAtrributes = nil
+		for _, webhook := range stage.SlackWebhookAtrributes {
+			w := *webhook
+			w.ID = nil
+			w.Destroy = nil
+			payload.SlackWebhookAtrributes = append(payload.SlackWebhookAtrributes, &w)
+		}
+
+		payload.ProjectID, err = remap(ids.Projects, id(stage.ProjectID), "project")
+		if err == nil {
+			payload.CommandIds, err = remapAll(ids.Commands, stage.CommandIds, "command")
+		}
+		if err == nil {
+			payload.DeployGroupIds, err = remapAll(ids.DeployGroups, stage.DeployGroupIds, "deploy group")
+		}
+		if err != nil {
+			return fmt.Errorf("stage %d: %s", id(stage.ID), err)
+		}
+
+		created, _, err := client.Stages.Upsert(&payload)
+		if err != nil {
+			return fmt.Errorf("stage %s: %s", str(stage.Permalink), err)
+		}
+		ids.Stages[id(stage.ID)] = *created.ID
+		createdStages[id(stage.ID)] = created
+	}
+
+	for _, stage := range archive.Stages {
+		if stage.NextStageIds == nil && stage.TemplateStageID == nil {
+			continue
+		}
+
+		created := createdStages[id(stage.ID)]
+		payload := &samson.Stage{ID: created.ID, CreatedAt: created.CreatedAt}
+		payload.NextStageIds, err = remapAll(ids.Stages, stage.NextStageIds, "stage")
+		if err == nil && stage.TemplateStageID != nil {
+			payload.TemplateStageID, err = remap(ids.Stages, *stage.TemplateStageID, "stage")
+		}
+		if err == nil {
+			_, _, err = client.Stages.Upsert(payload)
+		}
+		if err != nil {
+			return fmt.Errorf("stage %s: %s", str(stage.Permalink), err)
+		}
+	}
+
+	return nil
+}
+
+// restoreDeployGroups maps the archived deploy groups onto the target's
+// with the same permalink and creates the missing ones, kubernetes
+// clusters aren't archived so created groups have none
+func restoreDeployGroups(client *samson.Samson, archive *Archive, ids *IDMap) error {
+	var missing []*samson.DeployGroup
+	for _, deployGroup := range archive.DeployGroups {
+		if _, ok := ids.DeployGroups[id(deployGroup.ID)]; !ok {
+			missing = append(missing, deployGroup)
+		}
+	}
+	if len(missing) == 0 {
+		return nil
+	}
+
+	existing, _, err := client.DeployGroups.List()
+	if err != nil {
+		return err
+	}
+	byPermalink := map[string]int{}
+	for _, deployGroup := range existing {
+		byPermalink[str(deployGroup.Permalink)] = id(deployGroup.ID)
+	}
+
+	for _, deployGroup := range missing {
+		if targetID, ok := byPermalink[str(deployGroup.Permalink)]; ok {
+			ids.DeployGroups[id(deployGroup.ID)] = targetID
+			continue
+		}
+
+		payload := *deployGroup
+		payload.ID = nil
+		payload.CreatedAt = nil
+		payload.UpdatedAt = nil
+		payload.DeletedAt = nil
+		payload.ClusterDeployGroup = nil
+		payload.EnvironmentID, err = remap(ids.Environments, id(deployGroup.EnvironmentID), "environment")
+		if err != nil {
+			return fmt.Errorf("deploy group %s: %s", str(deployGroup.Permalink), err)
+		}
+
+		created, _, err := client.DeployGroups.Upsert(&payload)
+		if err != nil {
+			return fmt.Errorf("deploy group %s: %s", str(deployGroup.Permalink), err)
+		}
+		ids.DeployGroups[id(deployGroup.ID)] = *created.ID
+	}
+
+	return nil
+}
+
+// remapScope remaps an environment variable scope like Environment-1 or
+// DeployGroup-2
+func (ids *IDMap) remapScope(scope string) (string, error) {
+	parts := strings.SplitN(scope, "-", 2)
+	if len(parts) != 2 {
+		return scope, nil
+	}
+	old, err := strconv.Atoi(parts[1])
+	if err != nil {
+		return scope, nil
+	}
+
+	var mapping map[int]int
+	var resource string
+	switch parts[0] {
+	case "Environment":
+		mapping, resource = ids.Environments, "environment"
+	case "DeployGroup":
+		mapping, resource = ids.DeployGroups, "deploy group"
+	default:
+		return scope, nil
+	}
+
+	mapped, err := remap(mapping, old, resource)
+	if err != nil {
+		return "", err
+	}
+
+	return fmt.Sprintf("%s-%d", parts[0], *mapped), nil
+}
+
+func remap(ids map[int]int, old int, resource string) (*int, error) {
+	mapped, ok := ids[old]
+	if !ok {
+		return nil, fmt.Errorf("unknown %s %d", resource, old)
+	}
+
+	return samson.Int(mapped), nil
+}
+
+func remapAll(ids map[int]int, old []*int, resource string) ([]*int, error) {
+	if old == nil {
+		return nil, nil
+	}
+
+	mapped := make([]*int, 0, len(old))
+	for _, o := range old {
+		if o == nil {
+			continue
+		}
+		m, err := remap(ids, *o, resource)
+		if err != nil {
+			return nil, err
+		}
+		mapped = append(mapped, m)
+	}
+
+	return mapped, nil
+}
+
+func str(s *string) string {
+	if s == nil {
+		return ""
+	}
+
+	return *s
+}
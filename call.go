@@ -3,6 +3,7 @@ package samson
 import (
 	"encoding/json"
 	"io"
+	"io/ioutil"
 	"net/http"
 	"net/url"
 )
@@ -18,6 +19,8 @@ type Call struct {
 	req         *http.Request
 	res         *http.Response
 	err         *ErrorResponse
+	strict      bool
+	onWarnings  func(warnings []string)
 }
 
 func (call *Call) prepareRequest() error {
@@ -49,6 +52,10 @@ func (call *Call) Do(v interface{}) error {
 	if call.res.StatusCode >= 200 && call.res.StatusCode < 400 {
 		if v != nil {
 			defer call.res.Body.Close()
+			if call.strict {
+				return call.decodeStrict(v)
+			}
+
 			err = json.NewDecoder(call.res.Body).Decode(v)
 			if err != nil {
 				return err
@@ -61,6 +68,33 @@ func (call *Call) Do(v interface{}) error {
 	return call.handleError()
 }
 
+func (call *Call) decodeStrict(v interface{}) error {
+	data, err := ioutil.ReadAll(call.res.Body)
+	if err != nil {
+		return err
+	}
+
+	err = json.Unmarshal(data, v)
+	if err != nil {
+		return err
+	}
+
+	warnings, err := StrictWarnings(data, v)
+	if err != nil {
+		return err
+	}
+	if len(warnings) == 0 {
+		return nil
+	}
+
+	if call.onWarnings != nil {
+		call.onWarnings(warnings)
+		return nil
+	}
+
+	return StrictError{Warnings: warnings}
+}
+
 func (call *Call) handleError() error {
 	var e ErrorResponse
 	defer call.res.Body.Close()
@@ -68,6 +102,7 @@ func (call *Call) handleError() error {
 	if err != nil {
 		return err
 	}
+	e.StatusCode = call.res.StatusCode
 
 	call.err = &e
 
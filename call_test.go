@@ -68,6 +68,7 @@ func TestDo_fail(t *testing.T) {
 	err = call.Do(nil)
 	assert.NotNil(err)
 	assert.Equal(call.err.Error(), "error")
+	assert.Equal(500, err.(ErrorResponse).StatusCode)
 }
 
 func TestDo_fail_malformedrequest_1(t *testing.T) {
@@ -0,0 +1,40 @@
+package samson
+
+// Changeset model for the commits between two references
+type Changeset struct {
+	Previous     *string        `json:"previous,omitempty"`
+	Reference    *string        `json:"reference,omitempty"`
+	Commits      []*Commit      `json:"commits,omitempty"`
+	PullRequests []*PullRequest `json:"pull_requests,omitempty"`
+	Files        []*File        `json:"files,omitempty"`
+}
+
+// Commit model for changesets
+type Commit struct {
+	SHA         *string `json:"sha,omitempty"`
+	Summary     *string `json:"summary,omitempty"`
+	AuthorName  *string `json:"author_name,omitempty"`
+	AuthorEmail *string `json:"author_email,omitempty"`
+	URL         *string `json:"url,omitempty"`
+}
+
+// PullRequest model for changesets
+type PullRequest struct {
+	Number *int      `json:"number,omitempty"`
+	Title  *string   `json:"title,omitempty"`
+	URL    *string   `json:"url,omitempty"`
+	Users  []*string `json:"users,omitempty"`
+}
+
+// File model for changesets
+type File struct {
+	Filename  *string `json:"filename,omitempty"`
+	Status    *string `json:"status,omitempty"`
+	Additions *int    `json:"additions,omitempty"`
+	Deletions *int    `json:"deletions,omitempty"`
+}
+
+// IsEmpty returns whether there are no commits between the references
+func (c *Changeset) IsEmpty() bool {
+	return len(c.Commits) == 0
+}
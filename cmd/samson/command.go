@@ -0,0 +1,165 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"io"
+	"strconv"
+
+	samson "github.com/tolgaakyuz/samson-go"
+	"github.com/tolgaakyuz/samson-go/render"
+)
+
+// command runs an action against a resource
+type command struct {
+	client   *samson.Samson
+	resource *resource
+	stdout   io.Writer
+	stderr   io.Writer
+	output   render.Options
+}
+
+func (cmd *command) run(action string, args []string) int {
+	switch action {
+	case "list":
+		return cmd.list(args)
+	case "get":
+		return cmd.get(args)
+	case "create":
+		return cmd.create(args)
+	case "update":
+		return cmd.update(args)
+	case "delete":
+		return cmd.delete(args)
+	}
+
+	fmt.Fprintf(cmd.stderr, "unknown action %q for %s\n", action, cmd.resource.name)
+	return 2
+}
+
+func (cmd *command) list(args []string) int {
+	if len(args) != 0 {
+		return cmd.usage("list")
+	}
+
+	v, err := cmd.resource.list(cmd.client)
+	if err != nil {
+		return cmd.fail(err)
+	}
+
+	return cmd.print(v)
+}
+
+func (cmd *command) get(args []string) int {
+	if len(args) != 1 {
+		return cmd.usage("get <id>")
+	}
+	id, err := strconv.Atoi(args[0])
+	if err != nil {
+		return cmd.usage("get <id>")
+	}
+
+	v, err := cmd.resource.get(cmd.client, id)
+	if err != nil {
+		return cmd.fail(err)
+	}
+
+	return cmd.print(v)
+}
+
+func (cmd *command) create(args []string) int {
+	model := cmd.resource.model()
+	fs := cmd.flagSet("create", model)
+	err := fs.Parse(args)
+	if err != nil {
+		return 2
+	}
+	if fs.NArg() != 0 {
+		return cmd.usage("create [field flags]")
+	}
+
+	v, err := cmd.resource.upsert(cmd.client, model)
+	if err != nil {
+		return cmd.fail(err)
+	}
+
+	return cmd.print(v)
+}
+
+func (cmd *command) update(args []string) int {
+	if len(args) < 1 {
+		return cmd.usage("update <id> [field flags]")
+	}
+	id, err := strconv.Atoi(args[0])
+	if err != nil {
+		return cmd.usage("update <id> [field flags]")
+	}
+
+	changes := cmd.resource.model()
+	fs := cmd.flagSet("update", changes)
+	err = fs.Parse(args[1:])
+	if err != nil {
+		return 2
+	}
+	if fs.NArg() != 0 {
+		return cmd.usage("update <id> [field flags]")
+	}
+
+	model, err := cmd.resource.get(cmd.client, id)
+	if err != nil {
+		return cmd.fail(err)
+	}
+	mergeFields(model, changes)
+
+	v, err := cmd.resource.upsert(cmd.client, model)
+	if err != nil {
+		return cmd.fail(err)
+	}
+
+	return cmd.print(v)
+}
+
+func (cmd *command) delete(args []string) int {
+	if len(args) != 1 {
+		return cmd.usage("delete <id>")
+	}
+	id, err := strconv.Atoi(args[0])
+	if err != nil {
+		return cmd.usage("delete <id>")
+	}
+
+	err = cmd.resource.delete(cmd.client, id)
+	if err != nil {
+		return cmd.fail(err)
+	}
+
+	fmt.Fprintf(cmd.stdout, "deleted %s %d\n", cmd.resource.singular, id)
+	return 0
+}
+
+func (cmd *command) flagSet(action string, model interface{}) *flag.FlagSet {
+	fs := flag.NewFlagSet(cmd.resource.name+" "+action, flag.ContinueOnError)
+	fs.SetOutput(cmd.stderr)
+	fieldFlags(fs, model)
+
+	return fs
+}
+
+func (cmd *command) print(v interface{}) int {
+	err := render.Render(cmd.stdout, v, cmd.output)
+	if err != nil {
+		return cmd.fail(err)
+	}
+
+	return 0
+}
+
+func (cmd *command) usage(action string) int {
+	fmt.Fprintf(cmd.stderr, "usage: samson %s %s\n", cmd.resource.name, action)
+	return 2
+}
+
+func (cmd *command) fail(err error) int {
+	fmt.Fprintf(cmd.stderr, "error: %s\n", err)
+	return 1
+}
@@ -0,0 +1,58 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"io"
+
+	samson "github.com/tolgaakyuz/samson-go"
+	"github.com/tolgaakyuz/samson-go/config"
+)
+
+// runConfig plans or applies a declarative config file
+func runConfig(client *samson.Samson, args []string, stdout, stderr io.Writer) int {
+	if len(args) < 1 || (args[0] != "plan" && args[0] != "apply") {
+		fmt.Fprintln(stderr, "usage: samson config <plan|apply> -f samson.yml [-prune]")
+		return 2
+	}
+	action := args[0]
+
+	fs := flag.NewFlagSet("config "+action, flag.ContinueOnError)
+	fs.SetOutput(stderr)
+	file := fs.String("f", "samson.yml", "config file")
+	prune := fs.Bool("prune", false, "delete environments, global commands and projects missing from the config")
+	err := fs.Parse(args[1:])
+	if err != nil {
+		return 2
+	}
+	if fs.NArg() != 0 {
+		fmt.Fprintln(stderr, "usage: samson config <plan|apply> -f samson.yml [-prune]")
+		return 2
+	}
+
+	cfg, err := config.LoadFile(*file)
+	if err != nil {
+		fmt.Fprintf(stderr, "error: %s\n", err)
+		return 1
+	}
+
+	plan, err := config.NewPlan(client, cfg, config.Options{Prune: *prune})
+	if err != nil {
+		fmt.Fprintf(stderr, "error: %s\n", err)
+		return 1
+	}
+
+	plan.Write(stdout)
+	if action == "plan" || plan.Empty() {
+		return 0
+	}
+
+	err = plan.Apply()
+	if err != nil {
+		fmt.Fprintf(stderr, "error: %s\n", err)
+		return 1
+	}
+
+	fmt.Fprintln(stdout, "Applied.")
+	return 0
+}
@@ -0,0 +1,79 @@
+package main
+
+import (
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestConfig(t *testing.T) {
+	assert := assert.New(t)
+
+	dir, err := ioutil.TempDir("", "samson-cli")
+	assert.Nil(err)
+	defer os.RemoveAll(dir)
+
+	file := filepath.Join(dir, "samson.yml")
+	assert.Nil(ioutil.WriteFile(file, []byte("projects:\n  - permalink: example\n    name: Example\n"), 0644))
+
+	var requests []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests = append(requests, r.Method+" "+r.URL.Path)
+		if r.Method == "GET" {
+			fmt.Fprintf(w, `{"%s": []}`, strings.TrimSuffix(strings.TrimPrefix(r.URL.Path, "/"), ".json"))
+			return
+		}
+		fmt.Fprint(w, `{"id": 1, "permalink": "example"}`)
+	}))
+	defer server.Close()
+
+	code, stdout, stderr := runCLI(server, "config", "plan", "-f", file)
+	assert.Equal(0, code, stderr)
+	assert.Equal("+ create project example\n    name: \"Example\"\n    permalink: \"example\"\n\nPlan: 1 to create, 0 to update, 0 to delete.\n", stdout)
+	assert.NotContains(requests, "POST /projects.json")
+
+	code, stdout, stderr = runCLI(server, "config", "apply", "-f", file)
+	assert.Equal(0, code, stderr)
+	assert.True(strings.HasSuffix(stdout, "Applied.\n"))
+	assert.Contains(requests, "POST /projects.json")
+
+	code, _, stderr = runCLI(server, "config", "plan", "-f", filepath.Join(dir, "missing.yml"))
+	assert.Equal(1, code)
+	assert.Contains(stderr, "error: ")
+
+	for _, args := range [][]string{
+		{"config"},
+		{"config", "destroy"},
+		{"config", "plan", "-unknown"},
+		{"config", "plan", "extra"},
+	} {
+		code, _, _ = runCLI(server, args...)
+		assert.Equal(2, code, fmt.Sprint(args))
+	}
+}
+
+func TestConfig_fail(t *testing.T) {
+	assert := assert.New(t)
+
+	dir, err := ioutil.TempDir("", "samson-cli")
+	assert.Nil(err)
+	defer os.RemoveAll(dir)
+
+	file := filepath.Join(dir, "samson.yml")
+	assert.Nil(ioutil.WriteFile(file, []byte("projects: [{permalink: example}]\n"), 0644))
+
+	var requests []request
+	server := serve(500, `{"message": "boom"}`, &requests)
+	defer server.Close()
+
+	code, _, stderr := runCLI(server, "config", "apply", "-f", file)
+	assert.Equal(1, code)
+	assert.Equal("error: boom\n", stderr)
+}
@@ -0,0 +1,63 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"io"
+
+	samson "github.com/tolgaakyuz/samson-go"
+	"github.com/tolgaakyuz/samson-go/migrate"
+)
+
+// runCopy copies a project from the client's instance to another one
+func runCopy(client *samson.Samson, args []string, stdout, stderr io.Writer) int {
+	fs := flag.NewFlagSet("copy-project", flag.ContinueOnError)
+	fs.SetOutput(stderr)
+	targetURL := fs.String("target-url", "", "url of the samson to copy to")
+	targetToken := fs.String("target-token", "", "api token of the samson to copy to")
+	permalink := fs.String("permalink", "", "permalink of the copy, defaults to the project's")
+	fs.Usage = func() {
+		fmt.Fprintln(stderr, "usage: samson [flags] copy-project -target-url URL -target-token TOKEN [-permalink PERMALINK] <permalink>")
+		fs.PrintDefaults()
+	}
+
+	err := fs.Parse(args)
+	if err != nil {
+		return 2
+	}
+	if fs.NArg() != 1 || *targetURL == "" || *targetToken == "" {
+		fs.Usage()
+		return 2
+	}
+
+	target := samson.New(*targetToken)
+	target.BaseURL = *targetURL
+
+	result, err := migrate.CopyProject(client, target, fs.Arg(0), migrate.Options{Permalink: *permalink})
+	if conflicts, ok := err.(*migrate.ConflictError); ok {
+		fmt.Fprintln(stderr, "error: project can't be copied:")
+		for _, conflict := range conflicts.Conflicts {
+			fmt.Fprintf(stderr, "  %s\n", conflict)
+		}
+		return 1
+	}
+	if err != nil {
+		fmt.Fprintf(stderr, "error: %s\n", err)
+		return 1
+	}
+
+	// the copy is named as requested when the target leaves fields out
+	copied := fs.Arg(0)
+	if *permalink != "" {
+		copied = *permalink
+	}
+	if result.Project.Permalink != nil {
+		copied = *result.Project.Permalink
+	}
+	if result.Project.ID != nil {
+		copied += fmt.Sprintf(" (%d)", *result.Project.ID)
+	}
+
+	fmt.Fprintf(stdout, "copied project %s to %s as %s with %d stages\n", fs.Arg(0), *targetURL, copied, len(result.IDs.Stages))
+	return 0
+}
@@ -0,0 +1,82 @@
+package main
+
+import (
+	"bytes"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/tolgaakyuz/samson-go/internal/samsontest"
+)
+
+func TestCopyProject(t *testing.T) {
+	assert := assert.New(t)
+
+	source := samsontest.New()
+	source.Add("projects", `{"id": 1, "name": "Example", "permalink": "example", "created_at": "2018-03-26T11:52:01Z"}`)
+	source.Add("commands", `{"id": 2, "command": "./notify.sh"}`)
+	source.Add("stages", `{"id": 3, "name": "Production", "permalink": "production", "project_id": 1, "command_ids": [2]}`)
+	sourceServer := source.Start()
+	defer sourceServer.Close()
+
+	target := samsontest.New()
+	targetServer := target.Start()
+	defer targetServer.Close()
+
+	args := []string{"-url", sourceServer.URL, "copy-project", "-target-url", targetServer.URL, "-target-token", "t", "example"}
+
+	var stdout, stderr bytes.Buffer
+	code := run(args, &stdout, &stderr)
+	assert.Equal(1, code)
+	assert.Equal("error: project can't be copied:\n  stage example/production: global command \"./notify.sh\" not found in target\n", stderr.String())
+
+	target.Add("commands", `{"id": 4, "command": "./notify.sh"}`)
+	stdout.Reset()
+	stderr.Reset()
+	code = run(args, &stdout, &stderr)
+	assert.Equal(0, code, stderr.String())
+	assert.Equal(fmt.Sprintf("copied project example to %s as example (101) with 1 stages\n", targetServer.URL), stdout.String())
+
+	stderr.Reset()
+	code = run([]string{"-url", sourceServer.URL, "copy-project", "-target-url", targetServer.URL, "-target-token", "t", "missing"}, &stdout, &stderr)
+	assert.Equal(1, code)
+	assert.Equal("error: migrate: project missing not found in source\n", stderr.String())
+
+	for _, args := range [][]string{
+		{"copy-project"},
+		{"copy-project", "example"},
+		{"copy-project", "-target-url", targetServer.URL},
+		{"copy-project", "-target-url", targetServer.URL, "example"},
+		{"copy-project", "-unknown"},
+	} {
+		code = run(args, &stdout, &stderr)
+		assert.Equal(2, code, fmt.Sprint(args))
+	}
+}
+
+func TestCopyProject_partialResponse(t *testing.T) {
+	assert := assert.New(t)
+
+	source := samsontest.New()
+	source.Add("projects", `{"id": 1, "name": "Example", "permalink": "example", "created_at": "2018-03-26T11:52:01Z"}`)
+	sourceServer := source.Start()
+	defer sourceServer.Close()
+
+	// the target leaves id and permalink out of the fetched copy
+	target := samsontest.New()
+	targetServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == "GET" && r.URL.Path == "/projects/101.json" {
+			fmt.Fprint(w, `{"name": "Example"}`)
+			return
+		}
+		target.ServeHTTP(w, r)
+	}))
+	defer targetServer.Close()
+
+	var stdout, stderr bytes.Buffer
+	code := run([]string{"-url", sourceServer.URL, "copy-project", "-target-url", targetServer.URL, "-target-token", "t", "-permalink", "copy", "example"}, &stdout, &stderr)
+	assert.Equal(0, code, stderr.String())
+	assert.Equal(fmt.Sprintf("copied project example to %s as copy with 0 stages\n", targetServer.URL), stdout.String())
+}
@@ -0,0 +1,127 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+
+	samson "github.com/tolgaakyuz/samson-go"
+)
+
+// fieldFlags registers a flag for every scalar and int list field of the
+// model not set by samson, named after the field's json key with dashes
+func fieldFlags(fs *flag.FlagSet, model interface{}) {
+	v := reflect.ValueOf(model).Elem()
+	t := v.Type()
+
+	for i := 0; i < t.NumField(); i++ {
+		f := t.Field(i)
+		name := strings.Split(f.Tag.Get("json"), ",")[0]
+		if name == "" || name == "-" || samson.ServerFields[name] {
+			continue
+		}
+
+		value := &fieldValue{v: v.Field(i)}
+		if !value.supported() {
+			continue
+		}
+
+		fs.Var(value, strings.Replace(name, "_", "-", -1), fmt.Sprintf("sets %s.%s", t.Name(), f.Name))
+	}
+}
+
+// mergeFields copies every field set in changes onto model
+func mergeFields(model, changes interface{}) {
+	dst := reflect.ValueOf(model).Elem()
+	src := reflect.ValueOf(changes).Elem()
+
+	for i := 0; i < src.NumField(); i++ {
+		field := src.Field(i)
+		if (field.Kind() == reflect.Ptr || field.Kind() == reflect.Slice) && !field.IsNil() {
+			dst.Field(i).Set(field)
+		}
+	}
+}
+
+// fieldValue is a flag.Value writing to a model field
+type fieldValue struct {
+	v reflect.Value
+}
+
+func (f *fieldValue) supported() bool {
+	switch f.v.Kind() {
+	case reflect.Ptr:
+		switch f.v.Type().Elem().Kind() {
+		case reflect.String, reflect.Int, reflect.Bool:
+			return true
+		}
+	case reflect.Slice:
+		elem := f.v.Type().Elem()
+		return elem.Kind() == reflect.Ptr && elem.Elem().Kind() == reflect.Int
+	}
+
+	return false
+}
+
+// IsBoolFlag allows bool fields to be set without a value
+func (f *fieldValue) IsBoolFlag() bool {
+	return f.v.Kind() == reflect.Ptr && f.v.Type().Elem().Kind() == reflect.Bool
+}
+
+func (f *fieldValue) String() string {
+	if !f.v.IsValid() || f.v.IsNil() {
+		return ""
+	}
+
+	if f.v.Kind() == reflect.Slice {
+		parts := make([]string, f.v.Len())
+		for i := range parts {
+			parts[i] = fmt.Sprint(f.v.Index(i).Elem().Interface())
+		}
+		return strings.Join(parts, ",")
+	}
+
+	return fmt.Sprint(f.v.Elem().Interface())
+}
+
+func (f *fieldValue) Set(s string) error {
+	if f.v.Kind() == reflect.Slice {
+		ints := reflect.MakeSlice(f.v.Type(), 0, 0)
+		for _, part := range strings.Split(s, ",") {
+			if strings.TrimSpace(part) == "" {
+				continue
+			}
+			i, err := strconv.Atoi(strings.TrimSpace(part))
+			if err != nil {
+				return err
+			}
+			ints = reflect.Append(ints, reflect.ValueOf(&i))
+		}
+		f.v.Set(ints)
+
+		return nil
+	}
+
+	value := reflect.New(f.v.Type().Elem())
+	switch value.Elem().Kind() {
+	case reflect.String:
+		value.Elem().SetString(s)
+	case reflect.Int:
+		i, err := strconv.Atoi(s)
+		if err != nil {
+			return err
+		}
+		value.Elem().SetInt(int64(i))
+	case reflect.Bool:
+		b, err := strconv.ParseBool(s)
+		if err != nil {
+			return err
+		}
+		value.Elem().SetBool(b)
+	}
+	f.v.Set(value)
+
+	return nil
+}
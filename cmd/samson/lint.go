@@ -0,0 +1,71 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"io"
+	"os"
+
+	samson "github.com/tolgaakyuz/samson-go"
+	"github.com/tolgaakyuz/samson-go/backup"
+	"github.com/tolgaakyuz/samson-go/lint"
+)
+
+// runLint checks the client's instance or a snapshot against the builtin rules
+func runLint(client *samson.Samson, args []string, stdout, stderr io.Writer) int {
+	fs := flag.NewFlagSet("lint", flag.ContinueOnError)
+	fs.SetOutput(stderr)
+	file := fs.String("f", "", "backup snapshot to lint instead of the instance")
+	format := fs.String("format", "text", "report format, text or json")
+	failOn := fs.String("fail-on", "error", "lowest severity failing the run, one of info, warning, error")
+	fs.Usage = func() {
+		fmt.Fprintln(stderr, "usage: samson [flags] lint [-f snapshot.json] [-format text|json] [-fail-on SEVERITY]")
+		fs.PrintDefaults()
+	}
+
+	err := fs.Parse(args)
+	if err != nil {
+		return 2
+	}
+	if fs.NArg() != 0 || (*format != "text" && *format != "json") {
+		fs.Usage()
+		return 2
+	}
+	severity, err := lint.ParseSeverity(*failOn)
+	if err != nil {
+		fmt.Fprintln(stderr, err)
+		return 2
+	}
+
+	archive, err := lintArchive(client, *file)
+	if err != nil {
+		fmt.Fprintf(stderr, "error: %s\n", err)
+		return 1
+	}
+
+	report := lint.Run(archive, lint.Builtin())
+	if *format == "json" {
+		report.WriteJSON(stdout)
+	} else {
+		report.Write(stdout)
+	}
+
+	if report.Failed(severity) {
+		return 1
+	}
+	return 0
+}
+
+func lintArchive(client *samson.Samson, file string) (*backup.Archive, error) {
+	if file == "" {
+		return backup.Export(client)
+	}
+
+	f, err := os.Open(file)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	return backup.Read(f)
+}
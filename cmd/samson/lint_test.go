@@ -0,0 +1,67 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/tolgaakyuz/samson-go/internal/samsontest"
+)
+
+func TestLint(t *testing.T) {
+	assert := assert.New(t)
+
+	fake := samsontest.New()
+	fake.Add("projects", `{"id": 1, "name": "Example", "permalink": "example", "created_at": "2018-03-26T11:52:01Z"}`)
+	fake.Add("commands", `{"id": 2, "command": "./deploy.sh"}`)
+	fake.Add("stages", `{"id": 3, "name": "Staging", "permalink": "staging", "project_id": 1}`)
+	server := fake.Start()
+	defer server.Close()
+
+	var stdout, stderr bytes.Buffer
+	code := run([]string{"-url", server.URL, "lint"}, &stdout, &stderr)
+	assert.Equal(0, code, stderr.String())
+	assert.Equal("warning stage example/staging: stage runs no commands (stage-commands)\n\n0 errors, 1 warnings, 0 info.\n", stdout.String())
+
+	stdout.Reset()
+	code = run([]string{"-url", server.URL, "lint", "-fail-on", "warning", "-format", "json"}, &stdout, &stderr)
+	assert.Equal(1, code)
+
+	var report map[string][]map[string]interface{}
+	assert.Nil(json.Unmarshal(stdout.Bytes(), &report))
+	assert.Len(report["findings"], 1)
+	assert.Equal("stage-commands", report["findings"][0]["rule"])
+
+	dir, err := ioutil.TempDir("", "lint")
+	assert.Nil(err)
+	defer os.RemoveAll(dir)
+
+	file := filepath.Join(dir, "snapshot.json")
+	snapshot := `{"version": 1, "stages": [{"id": 1, "permalink": "production", "production": true, "confirm": true, "command_ids": [5]}]}`
+	assert.Nil(ioutil.WriteFile(file, []byte(snapshot), 0644))
+
+	stdout.Reset()
+	code = run([]string{"-url", server.URL, "lint", "-f", file}, &stdout, &stderr)
+	assert.Equal(1, code)
+	assert.Contains(stdout.String(), "error   stage #0/production: command 5 does not exist (unknown-command)\n")
+
+	stderr.Reset()
+	code = run([]string{"lint", "-f", filepath.Join(dir, "missing.json")}, &stdout, &stderr)
+	assert.Equal(1, code)
+	assert.Contains(stderr.String(), "error: ")
+
+	for _, args := range [][]string{
+		{"lint", "extra"},
+		{"lint", "-format", "yaml"},
+		{"lint", "-fail-on", "fatal"},
+		{"lint", "-unknown"},
+	} {
+		code = run(args, &stdout, &stderr)
+		assert.Equal(2, code, fmt.Sprint(args))
+	}
+}
@@ -0,0 +1,142 @@
+// Command samson is a command line client for the Samson deployment tool.
+//
+// Usage:
+//
+//	samson [-url URL] [-token TOKEN] [-o FORMAT] [-columns a,b] <resource> <action> [id] [flags]
+//
+// Resources are projects, stages, commands and environments. Actions are
+// list, get, create, update and delete. Model fields are set through flags
+// named after their json keys, e.g. -repository-url or -command-ids 1,2.
+// Results print as a table, json, yaml, csv or through a go template.
+//
+// A declarative YAML config is reconciled with `samson config plan` and
+// `samson config apply`, see the config package for its format.
+//
+// `samson copy-project` copies a project with its stages, commands and
+// environment variables to another samson instance.
+//
+// `samson lint` checks an instance, or a snapshot written by the backup
+// package, against the builtin policy rules of the lint package.
+package main
+
+import (
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"strings"
+
+	samson "github.com/tolgaakyuz/samson-go"
+	"github.com/tolgaakyuz/samson-go/render"
+)
+
+func main() {
+	os.Exit(run(os.Args[1:], os.Stdout, os.Stderr))
+}
+
+// run executes the command line and returns the exit code
+func run(args []string, stdout, stderr io.Writer) int {
+	fs := flag.NewFlagSet("samson", flag.ContinueOnError)
+	fs.SetOutput(stderr)
+	baseURL := fs.String("url", envOr("SAMSON_URL", "http://localhost:9080"), "samson url, defaults to $SAMSON_URL")
+	token := fs.String("token", os.Getenv("SAMSON_TOKEN"), "api token, defaults to $SAMSON_TOKEN")
+	output := fs.String("o", string(render.Table), fmt.Sprintf("output format, one of %s", formatNames()))
+	columns := fs.String("columns", "", "comma separated json field names to print")
+	tmpl := fs.String("template", "", "go template executed for every item with -o template")
+	fs.Usage = func() {
+		fmt.Fprintf(stderr, "usage: samson [flags] <%s> <list|get|create|update|delete> [id] [field flags]\n", strings.Join(resourceNames(), "|"))
+		fmt.Fprintln(stderr, "       samson [flags] config <plan|apply> -f samson.yml [-prune]")
+		fmt.Fprintln(stderr, "       samson [flags] copy-project -target-url URL -target-token TOKEN [-permalink PERMALINK] <permalink>")
+		fmt.Fprintln(stderr, "       samson [flags] lint [-f snapshot.json] [-format text|json] [-fail-on SEVERITY]")
+		fs.PrintDefaults()
+	}
+
+	err := fs.Parse(args)
+	if err != nil {
+		return 2
+	}
+	if fs.NArg() < 2 && fs.Arg(0) != "config" && fs.Arg(0) != "copy-project" && fs.Arg(0) != "lint" {
+		fs.Usage()
+		return 2
+	}
+
+	format, err := render.ParseFormat(*output)
+	if err != nil {
+		fmt.Fprintln(stderr, err)
+		return 2
+	}
+
+	client := samson.New(*token)
+	client.BaseURL = *baseURL
+
+	switch fs.Arg(0) {
+	case "config":
+		return runConfig(client, fs.Args()[1:], stdout, stderr)
+	case "copy-project":
+		return runCopy(client, fs.Args()[1:], stdout, stderr)
+	case "lint":
+		return runLint(client, fs.Args()[1:], stdout, stderr)
+	}
+
+	res, ok := resources[fs.Arg(0)]
+	if !ok {
+		fmt.Fprintf(stderr, "unknown resource %q\n", fs.Arg(0))
+		fs.Usage()
+		return 2
+	}
+
+	cmd := &command{
+		client:   client,
+		resource: res,
+		stdout:   stdout,
+		stderr:   stderr,
+		output: render.Options{
+			Format:   format,
+			Columns:  splitColumns(*columns),
+			Template: *tmpl,
+		},
+	}
+
+	return cmd.run(fs.Arg(1), fs.Args()[2:])
+}
+
+func resourceNames() []string {
+	names := make([]string, 0, len(resources))
+	for name := range resources {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	return names
+}
+
+func formatNames() string {
+	names := make([]string, len(render.Formats))
+	for i, format := range render.Formats {
+		names[i] = string(format)
+	}
+
+	return strings.Join(names, ", ")
+}
+
+func splitColumns(s string) []string {
+	var columns []string
+	for _, column := range strings.Split(s, ",") {
+		column = strings.TrimSpace(column)
+		if column != "" {
+			columns = append(columns, column)
+		}
+	}
+
+	return columns
+}
+
+func envOr(key, fallback string) string {
+	value := os.Getenv(key)
+	if value == "" {
+		return fallback
+	}
+
+	return value
+}
@@ -0,0 +1,241 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type request struct {
+	method  string
+	path    string
+	payload map[string]interface{}
+}
+
+// serve starts a server replying with the given body and recording requests
+func serve(status int, body string, requests *[]request) *httptest.Server {
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		req := request{method: r.Method, path: r.URL.Path}
+		data, _ := ioutil.ReadAll(r.Body)
+		if len(data) > 0 {
+			json.Unmarshal(data, &req.payload)
+		}
+		*requests = append(*requests, req)
+
+		w.WriteHeader(status)
+		fmt.Fprint(w, body)
+	}))
+}
+
+func runCLI(server *httptest.Server, args ...string) (int, string, string) {
+	var stdout, stderr bytes.Buffer
+	args = append([]string{"-url", server.URL, "-token", "token"}, args...)
+	code := run(args, &stdout, &stderr)
+
+	return code, stdout.String(), stderr.String()
+}
+
+func TestProjectsList(t *testing.T) {
+	assert := assert.New(t)
+
+	var requests []request
+	server := serve(200, `{"projects": [{"id": 1, "name": "Foo"}]}`, &requests)
+	defer server.Close()
+
+	code, stdout, stderr := runCLI(server, "-o", "json", "projects", "list")
+	assert.Equal(0, code)
+	assert.Empty(stderr)
+	assert.Len(requests, 1)
+	assert.Equal("GET", requests[0].method)
+	assert.Equal("/projects.json", requests[0].path)
+
+	var projects []map[string]interface{}
+	assert.Nil(json.Unmarshal([]byte(stdout), &projects))
+	assert.Len(projects, 1)
+	assert.Equal("Foo", projects[0]["name"])
+}
+
+func TestProjectsGet(t *testing.T) {
+	assert := assert.New(t)
+
+	var requests []request
+	server := serve(200, `{"id": 1, "name": "Foo"}`, &requests)
+	defer server.Close()
+
+	code, stdout, _ := runCLI(server, "-o", "json", "projects", "get", "1")
+	assert.Equal(0, code)
+	assert.Equal("/projects/1.json", requests[0].path)
+	assert.Contains(stdout, `"name": "Foo"`)
+}
+
+func TestProjectsCreate(t *testing.T) {
+	assert := assert.New(t)
+
+	var requests []request
+	server := serve(201, `{"id": 1, "name": "Foo"}`, &requests)
+	defer server.Close()
+
+	code, _, stderr := runCLI(server, "projects", "create", "-name", "Foo", "-repository-url", "git@github.com:foo/bar.git", "-include-new-deploy-groups")
+	assert.Equal(0, code, stderr)
+	assert.Len(requests, 1)
+	assert.Equal("POST", requests[0].method)
+	assert.Equal("/projects.json", requests[0].path)
+
+	project := requests[0].payload
+	assert.Equal("Foo", project["name"])
+	assert.Equal("git@github.com:foo/bar.git", project["repository_url"])
+	assert.Equal(true, project["include_new_deploy_groups"])
+	assert.Nil(project["description"])
+}
+
+func TestProjectsUpdate(t *testing.T) {
+	assert := assert.New(t)
+
+	var requests []request
+	server := serve(200, `{"id": 1, "name": "Foo", "description": "old", "created_at": "2018-03-26T11:52:01.477Z"}`, &requests)
+	defer server.Close()
+
+	code, _, stderr := runCLI(server, "projects", "update", "1", "-description", "new")
+	assert.Equal(0, code, stderr)
+	assert.Len(requests, 2)
+	assert.Equal("GET", requests[0].method)
+	assert.Equal("PUT", requests[1].method)
+	assert.Equal("/projects/1.json", requests[1].path)
+
+	project := requests[1].payload
+	assert.Equal("Foo", project["name"])
+	assert.Equal("new", project["description"])
+}
+
+func TestProjectsDelete(t *testing.T) {
+	assert := assert.New(t)
+
+	var requests []request
+	server := serve(200, ``, &requests)
+	defer server.Close()
+
+	code, stdout, _ := runCLI(server, "projects", "delete", "1")
+	assert.Equal(0, code)
+	assert.Equal("DELETE", requests[0].method)
+	assert.Equal("/projects/1.json", requests[0].path)
+	assert.Equal("deleted project 1\n", stdout)
+}
+
+func TestStagesCreate(t *testing.T) {
+	assert := assert.New(t)
+
+	var requests []request
+	server := serve(201, `{"id": 1, "name": "production"}`, &requests)
+	defer server.Close()
+
+	code, _, stderr := runCLI(server, "stages", "create", "-name", "production", "-project-id", "2", "-command-ids", "3,4", "-confirm=false")
+	assert.Equal(0, code, stderr)
+	assert.Equal("POST", requests[0].method)
+
+	stage := requests[0].payload
+	assert.Equal("production", stage["name"])
+	assert.Equal(float64(2), stage["project_id"])
+	assert.Equal([]interface{}{float64(3), float64(4)}, stage["command_ids"])
+	assert.Equal(false, stage["confirm"])
+}
+
+func TestCommandsUpdate(t *testing.T) {
+	assert := assert.New(t)
+
+	var requests []request
+	server := serve(200, `{"id": 3, "command": "echo hello", "project_id": "2"}`, &requests)
+	defer server.Close()
+
+	code, _, stderr := runCLI(server, "commands", "update", "3", "-command", "echo bye")
+	assert.Equal(0, code, stderr)
+	assert.Equal("PUT", requests[1].method)
+	assert.Equal("/commands/3.json", requests[1].path)
+
+	command := requests[1].payload
+	assert.Equal("echo bye", command["command"])
+	assert.Equal(float64(2), command["project_id"])
+}
+
+func TestEnvironmentsCreate(t *testing.T) {
+	assert := assert.New(t)
+
+	var requests []request
+	server := serve(201, `{"id": 1, "name": "Production", "production": "1"}`, &requests)
+	defer server.Close()
+
+	code, stdout, stderr := runCLI(server, "-o", "yaml", "environments", "create", "-name", "Production", "-production")
+	assert.Equal(0, code, stderr)
+	assert.Equal("POST", requests[0].method)
+	assert.Equal("/environments.json", requests[0].path)
+	assert.Equal("id: 1\nname: Production\nproduction: true\n", stdout)
+}
+
+func TestOutputFormats(t *testing.T) {
+	assert := assert.New(t)
+
+	var requests []request
+	server := serve(200, `{"projects": [{"id": 1, "name": "Foo", "permalink": "foo"}, {"id": 2, "name": "Bar", "permalink": "bar"}]}`, &requests)
+	defer server.Close()
+
+	_, stdout, _ := runCLI(server, "-columns", "id,permalink", "projects", "list")
+	assert.Equal("ID  PERMALINK\n1   foo\n2   bar\n", stdout)
+
+	_, stdout, _ = runCLI(server, "-o", "csv", "-columns", "permalink,name", "projects", "list")
+	assert.Equal("permalink,name\nfoo,Foo\nbar,Bar\n", stdout)
+
+	_, stdout, _ = runCLI(server, "-o", "template", "-template", "{{.Name}}", "projects", "list")
+	assert.Equal("Foo\nBar\n", stdout)
+
+	code, _, stderr := runCLI(server, "-columns", "colour", "projects", "list")
+	assert.Equal(1, code)
+	assert.Equal("error: unknown column \"colour\" for Project\n", stderr)
+
+	code, _, _ = runCLI(server, "-o", "xml", "projects", "list")
+	assert.Equal(2, code)
+}
+
+func TestRun_fail(t *testing.T) {
+	assert := assert.New(t)
+
+	var requests []request
+	server := serve(404, `{"message": "Not Found"}`, &requests)
+	defer server.Close()
+
+	code, _, stderr := runCLI(server, "projects", "get", "1")
+	assert.Equal(1, code)
+	assert.Equal("error: Not Found\n", stderr)
+
+	code, _, stderr = runCLI(server, "projects", "update", "1", "-name", "Foo")
+	assert.Equal(1, code)
+	assert.Len(requests, 2)
+
+	usages := [][]string{
+		{},
+		{"projects"},
+		{"releases", "list"},
+		{"projects", "rename"},
+		{"projects", "list", "extra"},
+		{"projects", "get"},
+		{"projects", "get", "one"},
+		{"projects", "delete", "one"},
+		{"projects", "update"},
+		{"projects", "update", "one"},
+		{"projects", "create", "-unknown", "x"},
+		{"projects", "create", "-token", "x"},
+		{"projects", "update", "1", "-star-count", "3"},
+		{"stages", "create", "-project-id", "two"},
+		{"stages", "create", "-command-ids", "1,x"},
+		{"-unknown"},
+	}
+	for _, args := range usages {
+		code, _, _ = runCLI(server, args...)
+		assert.Equal(2, code, fmt.Sprint(args))
+	}
+	assert.Len(requests, 2)
+}
@@ -0,0 +1,103 @@
+package main
+
+import (
+	samson "github.com/tolgaakyuz/samson-go"
+)
+
+// resource adapts a samson service to the generic cli actions
+type resource struct {
+	name     string
+	singular string
+	model    func() interface{}
+	list     func(client *samson.Samson) (interface{}, error)
+	get      func(client *samson.Samson, id int) (interface{}, error)
+	upsert   func(client *samson.Samson, v interface{}) (interface{}, error)
+	delete   func(client *samson.Samson, id int) error
+}
+
+var resources = map[string]*resource{
+	"projects": {
+		name:     "projects",
+		singular: "project",
+		model:    func() interface{} { return &samson.Project{} },
+		list: func(client *samson.Samson) (interface{}, error) {
+			projects, _, err := client.Projects.List()
+			return projects, err
+		},
+		get: func(client *samson.Samson, id int) (interface{}, error) {
+			project, _, err := client.Projects.Get(id)
+			return project, err
+		},
+		upsert: func(client *samson.Samson, v interface{}) (interface{}, error) {
+			project, _, err := client.Projects.Upsert(v.(*samson.Project))
+			return project, err
+		},
+		delete: func(client *samson.Samson, id int) error {
+			_, err := client.Projects.Delete(id)
+			return err
+		},
+	},
+	"stages": {
+		name:     "stages",
+		singular: "stage",
+		model:    func() interface{} { return &samson.Stage{} },
+		list: func(client *samson.Samson) (interface{}, error) {
+			stages, _, err := client.Stages.List()
+			return stages, err
+		},
+		get: func(client *samson.Samson, id int) (interface{}, error) {
+			stage, _, err := client.Stages.Get(id)
+			return stage, err
+		},
+		upsert: func(client *samson.Samson, v interface{}) (interface{}, error) {
+			stage, _, err := client.Stages.Upsert(v.(*samson.Stage))
+			return stage, err
+		},
+		delete: func(client *samson.Samson, id int) error {
+			_, err := client.Stages.Delete(id)
+			return err
+		},
+	},
+	"commands": {
+		name:     "commands",
+		singular: "command",
+		model:    func() interface{} { return &samson.Command{} },
+		list: func(client *samson.Samson) (interface{}, error) {
+			commands, _, err := client.Commands.List()
+			return commands, err
+		},
+		get: func(client *samson.Samson, id int) (interface{}, error) {
+			command, _, err := client.Commands.Get(id)
+			return command, err
+		},
+		upsert: func(client *samson.Samson, v interface{}) (interface{}, error) {
+			command, _, err := client.Commands.Upsert(v.(*samson.Command))
+			return command, err
+		},
+		delete: func(client *samson.Samson, id int) error {
+			_, err := client.Commands.Delete(id)
+			return err
+		},
+	},
+	"environments": {
+		name:     "environments",
+		singular: "environment",
+		model:    func() interface{} { return &samson.Environment{} },
+		list: func(client *samson.Samson) (interface{}, error) {
+			environments, _, err := client.Environments.List()
+			return environments, err
+		},
+		get: func(client *samson.Samson, id int) (interface{}, error) {
+			environment, _, err := client.Environments.Get(id)
+			return environment, err
+		},
+		upsert: func(client *samson.Samson, v interface{}) (interface{}, error) {
+			environment, _, err := client.Environments.Upsert(v.(*samson.Environment))
+			return environment, err
+		},
+		delete: func(client *samson.Samson, id int) error {
+			_, err := client.Environments.Delete(id)
+			return err
+		},
+	},
+}
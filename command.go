@@ -13,7 +13,23 @@ type CommandService service
 type Command struct {
 	ID        *int    `json:"id,omitempty"`
 	Command   *string `json:"command,omitempty"`
-	ProjectID *string `json:"project_id,omitempty"`
+	ProjectID *int    `json:"project_id,omitempty"`
+}
+
+// UnmarshalJSON decodes a command, accepting string project ids
+func (c *Command) UnmarshalJSON(data []byte) error {
+	type command Command
+	aux := struct {
+		*command
+		ProjectID json.RawMessage `json:"project_id,omitempty"`
+	}{command: (*command)(c)}
+
+	err := json.Unmarshal(data, &aux)
+	if err != nil {
+		return err
+	}
+
+	return decodeFlexInt(aux.ProjectID, &c.ProjectID)
 }
 
 // IsGlobal returns whether the command is defined as global or not
@@ -45,7 +45,7 @@ func ExampleCommandService_Upsert_create() {
 
 	command := &Command{
 		Command:   String("go lint"),
-		ProjectID: String("1"),
+		ProjectID: Int(1),
 	}
 
 	command, _, err := client.Commands.Upsert(command)
@@ -61,7 +61,7 @@ func ExampleCommandService_Upsert_update() {
 
 	command := &Command{
 		Command:   String("go lint"),
-		ProjectID: String("1"),
+		ProjectID: Int(1),
 	}
 
 	command, _, err := client.Commands.Upsert(command)
@@ -213,7 +213,7 @@ func TestCommandServiceCreate(t *testing.T) {
 		err := json.NewDecoder(r.Body).Decode(&payload)
 		assert.Nil(err)
 		assert.Equal(payload["command"], "test command")
-		assert.Equal(payload["project_id"], "1")
+		assert.Equal(payload["project_id"], float64(1))
 
 		w.WriteHeader(200)
 		fmt.Fprintln(w, readTestData("command.json"))
@@ -227,7 +227,7 @@ func TestCommandServiceCreate(t *testing.T) {
 
 	command := &Command{
 		Command:   String("test command"),
-		ProjectID: String("1"),
+		ProjectID: Int(1),
 	}
 
 	command, call, err := client.Commands.Upsert(command)
@@ -304,7 +304,7 @@ func TestCommandServiceUpdate(t *testing.T) {
 		err := json.NewDecoder(r.Body).Decode(&payload)
 		assert.Nil(err)
 		assert.Equal(payload["command"], "updated command")
-		assert.Equal(payload["project_id"], "1")
+		assert.Equal(payload["project_id"], float64(1))
 
 		w.WriteHeader(200)
 		fmt.Fprintln(w, readTestData("command.json"))
@@ -330,7 +330,7 @@ func TestCommandServiceUpsert_fail_1(t *testing.T) {
 
 	command := &Command{
 		Command:   String("test command"),
-		ProjectID: String("1"),
+		ProjectID: Int(1),
 	}
 
 	command, call, err := client.Commands.Upsert(command)
@@ -356,7 +356,7 @@ func TestCommandServiceUpsert_fail_2(t *testing.T) {
 
 	command := &Command{
 		Command:   String("test command"),
-		ProjectID: String("1"),
+		ProjectID: Int(1),
 	}
 
 	command, call, err := client.Commands.Upsert(command)
@@ -0,0 +1,273 @@
+// Package config reconciles a samson instance with a declarative YAML
+// description of its environments, commands, projects and stages.
+//
+// Resources use the json keys of the samson models and are matched with
+// the live instance by environment name, project permalink, stage
+// permalink within the project and command text within its project.
+// Commands get a local name which stages reference instead of ids:
+//
+//	environments:
+//	  - name: Production
+//	    production: true
+//	commands:
+//	  - name: notify
+//	    command: ./notify.sh
+//	projects:
+//	  - permalink: example
+//	    name: Example
+//	    repository_url: git@github.com:example/example.git
+//	    environment_variables_attributes:
+//	      - name: RAILS_ENV
+//	        value: production
+//	    commands:
+//	      - name: deploy
+//	        command: bundle exec cap deploy
+//	    stages:
+//	      - permalink: production
+//	        name: Production
+//	        commands: [deploy, notify]
+//	        slack_webhooks_attributes:
+//	          - webhook_url: https://hooks.slack.com/services/T/B/X
+//	            channel: deploys
+//	            after_deploy: true
+package config
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"strings"
+
+	samson "github.com/tolgaakyuz/samson-go"
+	yaml "gopkg.in/yaml.v2"
+)
+
+// Config is the desired state of a samson instance
+type Config struct {
+	Environments []*samson.Environment `json:"environments,omitempty"`
+	Commands     []*Command            `json:"commands,omitempty"`
+	Projects     []*Project            `json:"projects,omitempty"`
+}
+
+// Command is a command with the name stages reference it by
+type Command struct {
+	samson.Command
+	Name string `json:"name,omitempty"`
+}
+
+// UnmarshalJSON decodes the command and its name
+func (c *Command) UnmarshalJSON(data []byte) error {
+	var name struct {
+		Name string `json:"name,omitempty"`
+	}
+	err := json.Unmarshal(data, &name)
+	if err != nil {
+		return err
+	}
+	c.Name = name.Name
+
+	return json.Unmarshal(data, &c.Command)
+}
+
+// Project is a project with its commands and stages
+// Stages and commands of the project missing from the config are deleted
+// when the list is given, even empty
+type Project struct {
+	samson.Project
+	Commands []*Command `json:"commands,omitempty"`
+	Stages   []*Stage   `json:"stages,omitempty"`
+}
+
+// Stage is a stage referencing its commands by name
+type Stage struct {
+	samson.Stage
+	Commands []string `json:"commands,omitempty"`
+}
+
+// UnmarshalJSON decodes the stage and its command names
+func (s *Stage) UnmarshalJSON(data []byte) error {
+	var commands struct {
+		Commands []string `json:"commands,omitempty"`
+	}
+	err := json.Unmarshal(data, &commands)
+	if err != nil {
+		return err
+	}
+	s.Commands = commands.Commands
+
+	return json.Unmarshal(data, &s.Stage)
+}
+
+// LoadFile reads a config from a YAML file
+func LoadFile(path string) (*Config, error) {
+	data, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	return Load(data)
+}
+
+// Load parses and validates a YAML config
+func Load(data []byte) (*Config, error) {
+	var raw interface{}
+	err := yaml.Unmarshal(data, &raw)
+	if err != nil {
+		return nil, err
+	}
+
+	value, err := jsonValue(raw)
+	if err != nil {
+		return nil, err
+	}
+	if value == nil {
+		value = map[string]interface{}{}
+	}
+
+	data, err = json.Marshal(value)
+	if err != nil {
+		return nil, err
+	}
+
+	var cfg Config
+	err = json.Unmarshal(data, &cfg)
+	if err != nil {
+		return nil, err
+	}
+
+	warnings, err := samson.StrictWarnings(data, &cfg)
+	if err != nil {
+		return nil, err
+	}
+	if len(warnings) > 0 {
+		return nil, fmt.Errorf("invalid config: %s", strings.Join(warnings, "; "))
+	}
+
+	err = cfg.Validate()
+	if err != nil {
+		return nil, err
+	}
+
+	return &cfg, nil
+}
+
+// jsonValue converts decoded YAML into values encoding/json accepts
+func jsonValue(v interface{}) (interface{}, error) {
+	switch value := v.(type) {
+	case map[interface{}]interface{}:
+		m := make(map[string]interface{}, len(value))
+		for key, item := range value {
+			k, ok := key.(string)
+			if !ok {
+				return nil, fmt.Errorf("invalid config: key %v is not a string", key)
+			}
+			converted, err := jsonValue(item)
+			if err != nil {
+				return nil, err
+			}
+			m[k] = converted
+		}
+		return m, nil
+	case []interface{}:
+		for i, item := range value {
+			converted, err := jsonValue(item)
+			if err != nil {
+				return nil, err
+			}
+			value[i] = converted
+		}
+		return value, nil
+	}
+
+	return v, nil
+}
+
+// Validate checks resources are identifiable and command references resolve
+func (cfg *Config) Validate() error {
+	environments := map[string]bool{}
+	for _, environment := range cfg.Environments {
+		name := str(environment.Name)
+		if name == "" {
+			return fmt.Errorf("invalid config: environment without name")
+		}
+		if environments[name] {
+			return fmt.Errorf("invalid config: duplicate environment %s", name)
+		}
+		environments[name] = true
+	}
+
+	globals, err := commandNames(cfg.Commands, "")
+	if err != nil {
+		return err
+	}
+
+	projects := map[string]bool{}
+	for _, project := range cfg.Projects {
+		permalink := str(project.Permalink)
+		if permalink == "" {
+			return fmt.Errorf("invalid config: project without permalink")
+		}
+		if projects[permalink] {
+			return fmt.Errorf("invalid config: duplicate project %s", permalink)
+		}
+		projects[permalink] = true
+
+		names, err := commandNames(project.Commands, permalink+" ")
+		if err != nil {
+			return err
+		}
+
+		stages := map[string]bool{}
+		for _, stage := range project.Stages {
+			stagePermalink := str(stage.Permalink)
+			if stagePermalink == "" {
+				return fmt.Errorf("invalid config: stage without permalink in project %s", permalink)
+			}
+			if stages[stagePermalink] {
+				return fmt.Errorf("invalid config: duplicate stage %s/%s", permalink, stagePermalink)
+			}
+			stages[stagePermalink] = true
+
+			for _, name := range stage.Commands {
+				if !names[name] && !globals[name] {
+					return fmt.Errorf("invalid config: stage %s/%s references unknown command %s", permalink, stagePermalink, name)
+				}
+			}
+		}
+	}
+
+	return nil
+}
+
+func commandNames(commands []*Command, scope string) (map[string]bool, error) {
+	names := map[string]bool{}
+	texts := map[string]bool{}
+	for _, command := range commands {
+		text := str(command.Command.Command)
+		if text == "" {
+			return nil, fmt.Errorf("invalid config: %scommand %s without command", scope, command.Name)
+		}
+		if texts[text] {
+			return nil, fmt.Errorf("invalid config: duplicate %scommand %q", scope, text)
+		}
+		texts[text] = true
+
+		if command.Name == "" {
+			continue
+		}
+		if names[command.Name] {
+			return nil, fmt.Errorf("invalid config: duplicate %scommand name %s", scope, command.Name)
+		}
+		names[command.Name] = true
+	}
+
+	return names, nil
+}
+
+func str(s *string) string {
+	if s == nil {
+		return ""
+	}
+
+	return *s
+}
@@ -0,0 +1,133 @@
+package config
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+const example = `
+environments:
+  - name: Production
+    production: true
+commands:
+  - name: notify
+    command: ./notify.sh
+projects:
+  - permalink: example
+    name: Example
+    repository_url: git@github.com:example/example.git
+    environment_variables_attributes:
+      - name: RAILS_ENV
+        value: production
+    commands:
+      - name: deploy
+        command: bundle exec cap deploy
+    stages:
+      - permalink: production
+        name: Production
+        confirm: true
+        commands: [deploy, notify]
+        slack_webhooks_attributes:
+          - webhook_url: https://hooks.slack.com/services/T/B/X
+            channel: deploys
+            after_deploy: true
+`
+
+func TestLoad(t *testing.T) {
+	assert := assert.New(t)
+
+	cfg, err := Load([]byte(example))
+	assert.Nil(err)
+
+	assert.Len(cfg.Environments, 1)
+	assert.Equal("Production", *cfg.Environments[0].Name)
+	assert.True(*cfg.Environments[0].Production)
+
+	assert.Len(cfg.Commands, 1)
+	assert.Equal("notify", cfg.Commands[0].Name)
+	assert.Equal("./notify.sh", *cfg.Commands[0].Command.Command)
+
+	assert.Len(cfg.Projects, 1)
+	project := cfg.Projects[0]
+	assert.Equal("example", *project.Permalink)
+	assert.Equal("git@github.com:example/example.git", *project.RepositoryURL)
+	assert.Len(project.EnvironmentVariableAttributes, 1)
+	assert.Equal("deploy", project.Commands[0].Name)
+
+	assert.Len(project.Stages, 1)
+	stage := project.Stages[0]
+	assert.Equal("production", *stage.Permalink)
+	assert.True(*stage.Confirm)
+	assert.Equal([]string{"deploy", "notify"}, stage.Commands)
+	assert.Len(stage.SlackWebhookAtrributes, 1)
+	assert.Equal("deploys", *stage.SlackWebhookAtrributes[0].Channel)
+}
+
+func TestLoad_empty(t *testing.T) {
+	assert := assert.New(t)
+
+	cfg, err := Load([]byte(``))
+	assert.Nil(err)
+	assert.Empty(cfg.Projects)
+}
+
+func TestLoad_fail(t *testing.T) {
+	assert := assert.New(t)
+
+	cases := map[string]string{
+		"projects: [": "yaml: line 1: did not find expected node content",
+		"projets: []": "invalid config: Config: unknown field projets",
+		"projects: [{permalink: a, colour: red}]": "invalid config: Project: unknown field projects[0].colour",
+		"1: foo":                                                                   "invalid config: key 1 is not a string",
+		"environments: [{production: true}]":                                       "invalid config: environment without name",
+		"environments: [{name: a}, {name: a}]":                                     "invalid config: duplicate environment a",
+		"projects: [{name: a}]":                                                    "invalid config: project without permalink",
+		"projects: [{permalink: a}, {permalink: a}]":                               "invalid config: duplicate project a",
+		"projects: [{permalink: a, stages: [{name: b}]}]":                          "invalid config: stage without permalink in project a",
+		"commands: [{name: a}]":                                                    "invalid config: command a without command",
+		"commands: [{command: a}, {command: a}]":                                   `invalid config: duplicate command "a"`,
+		"commands: [{name: a, command: a}, {name: a, command: b}]":                 "invalid config: duplicate command name a",
+		"projects: [{permalink: a, commands: [{name: b}]}]":                        "invalid config: a command b without command",
+		"projects: [{permalink: a, stages: [{permalink: b}, {permalink: b}]}]":     "invalid config: duplicate stage a/b",
+		"projects: [{permalink: a, stages: [{permalink: b, commands: [deploy]}]}]": "invalid config: stage a/b references unknown command deploy",
+	}
+
+	for data, message := range cases {
+		_, err := Load([]byte(data))
+		assert.EqualError(err, message, data)
+	}
+
+	_, err := Load([]byte("projects: [{permalink: a, name: [1]}]"))
+	assert.NotNil(err)
+}
+
+func TestLoadFile(t *testing.T) {
+	assert := assert.New(t)
+
+	dir, err := ioutil.TempDir("", "samson-config")
+	assert.Nil(err)
+	defer os.RemoveAll(dir)
+
+	path := filepath.Join(dir, "samson.yml")
+	assert.Nil(ioutil.WriteFile(path, []byte(example), 0644))
+
+	cfg, err := LoadFile(path)
+	assert.Nil(err)
+	assert.Len(cfg.Projects, 1)
+
+	_, err = LoadFile(filepath.Join(dir, "missing.yml"))
+	assert.NotNil(err)
+}
+
+func ExampleLoadFile() {
+	cfg, err := LoadFile("samson.yml")
+	if err != nil {
+		panic(err)
+	}
+
+	_ = cfg
+}
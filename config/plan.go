@@ -0,0 +1,809 @@
+package config
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"reflect"
+	"sort"
+	"strings"
+
+	samson "github.com/tolgaakyuz/samson-go"
+)
+
+// Action is what a change does to a resource
+type Action string
+
+// Actions
+const (
+	Create Action = "create"
+	Update Action = "update"
+	Delete Action = "delete"
+)
+
+var actionSymbols = map[Action]string{
+	Create: "+",
+	Update: "~",
+	Delete: "-",
+}
+
+// Change is a planned change of a single resource
+type Change struct {
+	Action   Action
+	Resource string
+	Name     string
+	Fields   []samson.FieldChange
+
+	apply func(st *state) error
+}
+
+// Options configures planning
+type Options struct {
+	// Prune deletes environments, global commands and projects missing
+	// from the config, by default only resources of configured projects
+	// are deleted
+	Prune bool
+}
+
+// Plan is the list of changes bringing the instance to the config, in the
+// order they are applied
+type Plan struct {
+	Changes []*Change
+
+	client *samson.Samson
+	state  *state
+}
+
+// state tracks the ids resources are referenced by, filled in while
+// planning for existing resources and while applying for created ones
+type state struct {
+	projects map[string]int
+	commands map[string]int
+}
+
+func commandKey(project, name string) string {
+	return project + "/" + name
+}
+
+// commandIDs resolves command names of a project's stage
+func (st *state) commandIDs(project string, names []string) ([]*int, error) {
+	ids := make([]*int, len(names))
+	for i, name := range names {
+		id, ok := st.commands[commandKey(project, name)]
+		if !ok {
+			id, ok = st.commands[commandKey("", name)]
+		}
+		if !ok {
+			return nil, fmt.Errorf("unknown command %s", name)
+		}
+		ids[i] = samson.Int(id)
+	}
+
+	return ids, nil
+}
+
+// live is the current state of the instance
+type live struct {
+	environments map[string]*samson.Environment
+	commands     []*samson.Command
+	projects     map[string]*samson.Project
+	stages       []*samson.Stage
+}
+
+func fetch(client *samson.Samson) (*live, error) {
+	l := &live{
+		environments: map[string]*samson.Environment{},
+		projects:     map[string]*samson.Project{},
+	}
+
+	environments, _, err := client.Environments.List()
+	if err != nil {
+		return nil, err
+	}
+	for _, environment := range environments {
+		l.environments[str(environment.Name)] = environment
+	}
+
+	l.commands, _, err = client.Commands.List()
+	if err != nil {
+		return nil, err
+	}
+
+	projects, _, err := client.Projects.List()
+	if err != nil {
+		return nil, err
+	}
+	for _, project := range projects {
+		l.projects[str(project.Permalink)] = project
+	}
+
+	l.stages, _, err = client.Stages.List()
+	if err != nil {
+		return nil, err
+	}
+
+	return l, nil
+}
+
+// projectCommands returns the live commands of a project, nil for global ones
+func (l *live) projectCommands(projectID *int) []*samson.Command {
+	var commands []*samson.Command
+	for _, command := range l.commands {
+		if projectID == nil && command.ProjectID == nil ||
+			projectID != nil && command.ProjectID != nil && *command.ProjectID == *projectID {
+			commands = append(commands, command)
+		}
+	}
+
+	return commands
+}
+
+func (l *live) projectStages(projectID int) []*samson.Stage {
+	var stages []*samson.Stage
+	for _, stage := range l.stages {
+		if stage.ProjectID != nil && *stage.ProjectID == projectID {
+			stages = append(stages, stage)
+		}
+	}
+
+	return stages
+}
+
+// planner builds the changes of a plan grouped by when they're applied
+type planner struct {
+	client *samson.Samson
+	cfg    *Config
+	opts   Options
+	live   *live
+	state  *state
+
+	// commandNames maps live command ids to config names for display
+	commandNames map[int]string
+
+	environments   []*Change
+	projects       []*Change
+	commands       []*Change
+	stages         []*Change
+	deleteStages   []*Change
+	deleteCommands []*Change
+	deleteProjects []*Change
+	deleteEnvs     []*Change
+}
+
+// NewPlan compares the config with the instance and plans the changes
+func NewPlan(client *samson.Samson, cfg *Config, opts Options) (*Plan, error) {
+	l, err := fetch(client)
+	if err != nil {
+		return nil, err
+	}
+
+	p := &planner{
+		client: client,
+		cfg:    cfg,
+		opts:   opts,
+		live:   l,
+		state: &state{
+			projects: map[string]int{},
+			commands: map[string]int{},
+		},
+		commandNames: map[int]string{},
+	}
+
+	p.planEnvironments()
+	p.planCommands("", nil, cfg.Commands, opts.Prune)
+	for _, project := range cfg.Projects {
+		err = p.planProject(project)
+		if err != nil {
+			return nil, err
+		}
+	}
+	if opts.Prune {
+		p.pruneProjects()
+	}
+
+	var changes []*Change
+	for _, group := range [][]*Change{
+		p.environments, p.projects, p.commands, p.stages,
+		p.deleteStages, p.deleteCommands, p.deleteProjects, p.deleteEnvs,
+	} {
+		changes = append(changes, group...)
+	}
+
+	return &Plan{Changes: changes, client: client, state: p.state}, nil
+}
+
+func (p *planner) planEnvironments() {
+	desired := map[string]bool{}
+	for _, environment := range p.cfg.Environments {
+		name := str(environment.Name)
+		desired[name] = true
+
+		payload := *environment
+		live, ok := p.live.environments[name]
+		if !ok {
+			payload.ID = nil
+			p.environments = append(p.environments, &Change{
+				Action:   Create,
+				Resource: "environment",
+				Name:     name,
+				Fields:   desiredFields(samson.DiffEnvironment(nil, &payload)),
+				apply: func(st *state) error {
+					_, _, err := p.client.Environments.Upsert(&payload)
+					return err
+				},
+			})
+			continue
+		}
+
+		fields := desiredFields(samson.DiffEnvironment(live, environment))
+		if len(fields) == 0 {
+			continue
+		}
+		payload.ID = live.ID
+		p.environments = append(p.environments, &Change{
+			Action:   Update,
+			Resource: "environment",
+			Name:     name,
+			Fields:   fields,
+			apply: func(st *state) error {
+				_, _, err := p.client.Environments.Upsert(&payload)
+				return err
+			},
+		})
+	}
+
+	if !p.opts.Prune {
+		return
+	}
+	for name, live := range p.live.environments {
+		if desired[name] {
+			continue
+		}
+		id := *live.ID
+		p.deleteEnvs = append(p.deleteEnvs, &Change{
+			Action:   Delete,
+			Resource: "environment",
+			Name:     name,
+			apply: func(st *state) error {
+				_, err := p.client.Environments.Delete(id)
+				return err
+			},
+		})
+	}
+	sortChanges(p.deleteEnvs)
+}
+
+// planCommands plans the commands of a project, or the global ones when
+// project is empty, matching live commands by their text
+func (p *planner) planCommands(project string, projectID *int, commands []*Command, prune bool) {
+	var live []*samson.Command
+	if project == "" || projectID != nil {
+		live = p.live.projectCommands(projectID)
+	}
+
+	byText := map[string]*samson.Command{}
+	for _, command := range live {
+		byText[str(command.Command)] = command
+	}
+
+	desired := map[string]bool{}
+	for _, command := range commands {
+		text := str(command.Command.Command)
+		desired[text] = true
+		key := commandKey(project, command.Name)
+
+		if existing, ok := byText[text]; ok {
+			if command.Name != "" {
+				p.state.commands[key] = *existing.ID
+				p.commandNames[*existing.ID] = command.Name
+			}
+			continue
+		}
+
+		name := command.Name
+		p.commands = append(p.commands, &Change{
+			Action:   Create,
+			Resource: "command",
+			Name:     commandLabel(project, name, text),
+			Fields:   []samson.FieldChange{{Field: "command", After: text}},
+			apply: func(st *state) error {
+				payload := &samson.Command{Command: samson.String(text)}
+				if project != "" {
+					payload.ProjectID = samson.Int(st.projects[project])
+				}
+				created, _, err := p.client.Commands.Upsert(payload)
+				if err != nil {
+					return err
+				}
+				if name != "" {
+					st.commands[key] = *created.ID
+				}
+				return nil
+			},
+		})
+	}
+
+	if !prune {
+		return
+	}
+	for _, command := range live {
+		text := str(command.Command)
+		if desired[text] {
+			continue
+		}
+		id := *command.ID
+		p.deleteCommands = append(p.deleteCommands, &Change{
+			Action:   Delete,
+			Resource: "command",
+			Name:     commandLabel(project, "", text),
+			apply: func(st *state) error {
+				_, err := p.client.Commands.Delete(id)
+				return err
+			},
+		})
+	}
+}
+
+func commandLabel(project, name, text string) string {
+	label := name
+	if label == "" {
+		label = fmt.Sprintf("%q", text)
+	}
+	if project != "" {
+		label = project + "/" + label
+	}
+
+	return label
+}
+
+func (p *planner) planProject(project *Project) error {
+	permalink := str(project.Permalink)
+	payload := project.Project
+	payload.ID = nil
+	payload.CreatedAt = nil
+	payload.UpdatedAt = nil
+	payload.EnvironmentVariableAttributes = newEnvironmentVariables(project.EnvironmentVariableAttributes)
+
+	live, ok := p.live.projects[permalink]
+	if !ok {
+		fields := desiredFields(samson.OmitFields(samson.DiffProject(nil, &payload), "environment_variables_attributes"))
+		variables, _ := diffEnvironmentVariables(project.EnvironmentVariableAttributes, nil)
+		p.projects = append(p.projects, &Change{
+			Action:   Create,
+			Resource: "project",
+			Name:     permalink,
+			Fields:   append(fields, variables...),
+			apply: func(st *state) error {
+				created, _, err := p.client.Projects.Upsert(&payload)
+				if err != nil {
+					return err
+				}
+				st.projects[permalink] = *created.ID
+				return nil
+			},
+		})
+
+		p.planCommands(permalink, nil, project.Commands, false)
+		for _, stage := range project.Stages {
+			p.planStage(permalink, stage, nil)
+		}
+		return nil
+	}
+
+	p.state.projects[permalink] = *live.ID
+	full, _, err := p.client.Projects.Get(*live.ID)
+	if err != nil {
+		return err
+	}
+
+	fields := desiredFields(samson.OmitFields(samson.DiffProject(full, &project.Project), "environment_variables_attributes"))
+	if project.EnvironmentVariableAttributes != nil {
+		changes, attributes := diffEnvironmentVariables(project.EnvironmentVariableAttributes, full.EnvironmentVariableAttributes)
+		fields = append(fields, changes...)
+		payload.EnvironmentVariableAttributes = attributes
+	}
+	if len(fields) > 0 {
+		payload.ID = full.ID
+		payload.CreatedAt = full.CreatedAt
+		p.projects = append(p.projects, &Change{
+			Action:   Update,
+			Resource: "project",
+			Name:     permalink,
+			Fields:   fields,
+			apply: func(st *state) error {
+				_, _, err := p.client.Projects.Upsert(&payload)
+				return err
+			},
+		})
+	}
+
+	p.planCommands(permalink, live.ID, project.Commands, project.Commands != nil)
+
+	liveStages := map[string]*samson.Stage{}
+	for _, stage := range p.live.projectStages(*live.ID) {
+		liveStages[str(stage.Permalink)] = stage
+	}
+
+	desired := map[string]bool{}
+	for _, stage := range project.Stages {
+		desired[str(stage.Permalink)] = true
+		p.planStage(permalink, stage, liveStages[str(stage.Permalink)])
+	}
+
+	if project.Stages == nil {
+		return nil
+	}
+	var deletes []*Change
+	for stagePermalink, stage := range liveStages {
+		if desired[stagePermalink] {
+			continue
+		}
+		id := *stage.ID
+		deletes = append(deletes, &Change{
+			Action:   Delete,
+			Resource: "stage",
+			Name:     permalink + "/" + stagePermalink,
+			apply: func(st *state) error {
+				_, err := p.client.Stages.Delete(id)
+				return err
+			},
+		})
+	}
+	sortChanges(deletes)
+	p.deleteStages = append(p.deleteStages, deletes...)
+
+	return nil
+}
+
+func (p *planner) planStage(project string, stage *Stage, live *samson.Stage) {
+	name := project + "/" + str(stage.Permalink)
+	payload := stage.Stage
+	payload.ID = nil
+	payload.CreatedAt = nil
+	payload.UpdatedAt = nil
+	payload.SlackWebhookAtrributes = newSlackWebhooks(stage.SlackWebhookAtrributes)
+	names := stage.Commands
+
+	if live == nil {
+		fields := desiredFields(samson.OmitFields(samson.DiffStage(nil, &payload), stageReferences...))
+		if names != nil {
+			fields = append(fields, samson.FieldChange{Field: "commands", After: names})
+		}
+		p.stages = append(p.stages, &Change{
+			Action:   Create,
+			Resource: "stage",
+			Name:     name,
+			Fields:   fields,
+			apply: func(st *state) error {
+				payload.ProjectID = samson.Int(st.projects[project])
+				if names != nil {
+					ids, err := st.commandIDs(project, names)
+					if err != nil {
+						return err
+					}
+					payload.CommandIds = ids
+				}
+				_, _, err := p.client.Stages.Upsert(&payload)
+				return err
+			},
+		})
+		return
+	}
+
+	fields := desiredFields(samson.OmitFields(samson.DiffStage(live, &stage.Stage), stageReferences...))
+	if names != nil {
+		current := p.liveCommandNames(live.CommandIds)
+		if !reflect.DeepEqual(current, names) {
+			fields = append(fields, samson.FieldChange{Field: "commands", Before: current, After: names})
+		}
+	}
+	if stage.SlackWebhookAtrributes != nil {
+		changes, attributes := diffSlackWebhooks(stage.SlackWebhookAtrributes, live.SlackWebhookAtrributes)
+		fields = append(fields, changes...)
+		payload.SlackWebhookAtrributes = attributes
+	}
+	if len(fields) == 0 {
+		return
+	}
+
+	payload.ID = live.ID
+	payload.CreatedAt = live.CreatedAt
+	payload.ProjectID = live.ProjectID
+	p.stages = append(p.stages, &Change{
+		Action:   Update,
+		Resource: "stage",
+		Name:     name,
+		Fields:   fields,
+		apply: func(st *state) error {
+			if names != nil {
+				ids, err := st.commandIDs(project, names)
+				if err != nil {
+					return err
+				}
+				payload.CommandIds = ids
+			}
+			_, _, err := p.client.Stages.Upsert(&payload)
+			return err
+		},
+	})
+}
+
+// liveCommandNames names live command ids after the config, unknown
+// commands show as their id
+func (p *planner) liveCommandNames(ids []*int) []string {
+	names := make([]string, 0, len(ids))
+	for _, id := range ids {
+		if id == nil {
+			continue
+		}
+		name, ok := p.commandNames[*id]
+		if !ok {
+			name = fmt.Sprintf("#%d", *id)
+		}
+		names = append(names, name)
+	}
+
+	return names
+}
+
+func (p *planner) pruneProjects() {
+	desired := map[string]bool{}
+	for _, project := range p.cfg.Projects {
+		desired[str(project.Permalink)] = true
+	}
+
+	for permalink, project := range p.live.projects {
+		if desired[permalink] {
+			continue
+		}
+		id := *project.ID
+		p.deleteProjects = append(p.deleteProjects, &Change{
+			Action:   Delete,
+			Resource: "project",
+			Name:     permalink,
+			apply: func(st *state) error {
+				_, err := p.client.Projects.Delete(id)
+				return err
+			},
+		})
+	}
+	sortChanges(p.deleteProjects)
+}
+
+func sortChanges(changes []*Change) {
+	sort.Slice(changes, func(i, j int) bool {
+		return changes[i].Name < changes[j].Name
+	})
+}
+
+// Empty returns whether the instance already matches the config
+func (plan *Plan) Empty() bool {
+	return len(plan.Changes) == 0
+}
+
+// Apply makes the planned changes, stopping at the first failure
+func (plan *Plan) Apply() error {
+	for _, change := range plan.Changes {
+		err := change.apply(plan.state)
+		if err != nil {
+			return fmt.Errorf("%s %s %s: %s", change.Action, change.Resource, change.Name, err)
+		}
+	}
+
+	return nil
+}
+
+// Write prints the plan in a human readable form
+func (plan *Plan) Write(w io.Writer) error {
+	if plan.Empty() {
+		_, err := fmt.Fprintln(w, "No changes.")
+		return err
+	}
+
+	counts := map[Action]int{}
+	for _, change := range plan.Changes {
+		counts[change.Action]++
+		fmt.Fprintf(w, "%s %s %s %s\n", actionSymbols[change.Action], change.Action, change.Resource, change.Name)
+		for _, field := range change.Fields {
+			if change.Action == Create {
+				fmt.Fprintf(w, "    %s: %s\n", field.Field, formatValue(field.After))
+				continue
+			}
+			fmt.Fprintf(w, "    %s: %s -> %s\n", field.Field, formatValue(field.Before), formatValue(field.After))
+		}
+	}
+
+	_, err := fmt.Fprintf(w, "\nPlan: %d to create, %d to update, %d to delete.\n", counts[Create], counts[Update], counts[Delete])
+	return err
+}
+
+func (plan *Plan) String() string {
+	var buf bytes.Buffer
+	plan.Write(&buf)
+
+	return buf.String()
+}
+
+func formatValue(v interface{}) string {
+	if v == nil {
+		return "(none)"
+	}
+
+	data, err := json.Marshal(v)
+	if err != nil {
+		return fmt.Sprint(v)
+	}
+
+	return string(data)
+}
+
+// stage fields planned separately
+var stageReferences = []string{"project_id", "command_ids", "slack_webhooks_attributes"}
+
+// desiredFields drops the changes of fields unset in the config, which are
+// left alone
+func desiredFields(changes []samson.FieldChange) []samson.FieldChange {
+	var fields []samson.FieldChange
+	for _, change := range changes {
+		if change.After != nil {
+			fields = append(fields, change)
+		}
+	}
+
+	return fields
+}
+
+// newEnvironmentVariables copies variables dropping ids from the config
+func newEnvironmentVariables(variables []*samson.EnvironmentVariable) []*samson.EnvironmentVariable {
+	if variables == nil {
+		return nil
+	}
+
+	copies := make([]*samson.EnvironmentVariable, len(variables))
+	for i, variable := range variables {
+		c := *variable
+		c.ID = nil
+		c.Destroy = nil
+		copies[i] = &c
+	}
+
+	return copies
+}
+
+func environmentVariableKey(variable *samson.EnvironmentVariable) string {
+	key := str(variable.Name)
+	if scope := str(variable.ScopeTypeAndID); scope != "" {
+		key += " (" + scope + ")"
+	}
+
+	return key
+}
+
+// diffEnvironmentVariables returns the changes of the variables and the
+// nested attributes making them
+func diffEnvironmentVariables(desired, live []*samson.EnvironmentVariable) ([]samson.FieldChange, []*samson.EnvironmentVariable) {
+	byKey := map[string]*samson.EnvironmentVariable{}
+	for _, variable := range live {
+		byKey[environmentVariableKey(variable)] = variable
+	}
+
+	var changes []samson.FieldChange
+	var attributes []*samson.EnvironmentVariable
+	wanted := map[string]bool{}
+	for _, variable := range desired {
+		key := environmentVariableKey(variable)
+		wanted[key] = true
+		field := "environment variable " + key
+
+		existing, ok := byKey[key]
+		if !ok {
+			changes = append(changes, samson.FieldChange{Field: field, After: str(variable.Value)})
+			attributes = append(attributes, &samson.EnvironmentVariable{
+				Name:           variable.Name,
+				Value:          variable.Value,
+				ScopeTypeAndID: variable.ScopeTypeAndID,
+			})
+			continue
+		}
+
+		if str(existing.Value) != str(variable.Value) {
+			changes = append(changes, samson.FieldChange{Field: field, Before: str(existing.Value), After: str(variable.Value)})
+			attributes = append(attributes, &samson.EnvironmentVariable{
+				ID:             existing.ID,
+				Name:           variable.Name,
+				Value:          variable.Value,
+				ScopeTypeAndID: variable.ScopeTypeAndID,
+			})
+		}
+	}
+
+	for _, variable := range live {
+		key := environmentVariableKey(variable)
+		if wanted[key] {
+			continue
+		}
+		changes = append(changes, samson.FieldChange{Field: "environment variable " + key, Before: str(variable.Value)})
+		attributes = append(attributes, &samson.EnvironmentVariable{ID: variable.ID, Destroy: samson.Bool(true)})
+	}
+
+	return changes, attributes
+}
+
+// newSlackWebhooks copies webhooks dropping ids from the config
+func newSlackWebhooks(webhooks []*samson.SlackWebhookAttribute) []*samson.SlackWebhookAttribute {
+	if webhooks == nil {
+		return nil
+	}
+
+	copies := make([]*samson.SlackWebhookAttribute, len(webhooks))
+	for i, webhook := range webhooks {
+		c := *webhook
+		c.ID = nil
+		c.Destroy = nil
+		copies[i] = &c
+	}
+
+	return copies
+}
+
+func slackWebhookKey(webhook *samson.SlackWebhookAttribute) string {
+	key := str(webhook.WebhookURL)
+	if channel := str(webhook.Channel); channel != "" {
+		key += " #" + channel
+	}
+
+	return key
+}
+
+// diffSlackWebhooks returns the changes of the webhooks and the nested
+// attributes making them
+func diffSlackWebhooks(desired, live []*samson.SlackWebhookAttribute) ([]samson.FieldChange, []*samson.SlackWebhookAttribute) {
+	var changes []samson.FieldChange
+	for _, change := range samson.DiffStage(&samson.Stage{SlackWebhookAtrributes: live}, &samson.Stage{SlackWebhookAtrributes: desired}) {
+		path := strings.TrimPrefix(change.Field, "slack_webhooks_attributes[")
+		end := strings.LastIndex(path, "]")
+		field := strings.TrimPrefix(path[end+1:], ".")
+		if field != "" && change.After == nil {
+			continue
+		}
+
+		change.Field = "slack webhook " + path[:end]
+		if field != "" {
+			change.Field += " " + field
+		}
+		changes = append(changes, change)
+	}
+
+	// webhooks sharing a key are matched in order like the diff does, the
+	// live ones left over are destroyed
+	byKey := map[string][]*samson.SlackWebhookAttribute{}
+	for _, webhook := range live {
+		key := slackWebhookKey(webhook)
+		byKey[key] = append(byKey[key], webhook)
+	}
+
+	var attributes []*samson.SlackWebhookAttribute
+	for _, webhook := range desired {
+		key := slackWebhookKey(webhook)
+
+		c := *webhook
+		c.ID = nil
+		c.Destroy = nil
+		if existing := byKey[key]; len(existing) > 0 {
+			c.ID = existing[0].ID
+			byKey[key] = existing[1:]
+		}
+		attributes = append(attributes, &c)
+	}
+
+	for _, webhook := range live {
+		for _, left := range byKey[slackWebhookKey(webhook)] {
+			if left == webhook {
+				attributes = append(attributes, &samson.SlackWebhookAttribute{ID: webhook.ID, Destroy: samson.Bool(true)})
+			}
+		}
+	}
+
+	return changes, attributes
+}
@@ -0,0 +1,282 @@
+package config
+
+import (
+	"bytes"
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	samson "github.com/tolgaakyuz/samson-go"
+	"github.com/tolgaakyuz/samson-go/internal/samsontest"
+)
+
+func newTestPlan(f *samsontest.Server, data string, opts Options) (*Plan, error) {
+	server := f.Start()
+
+	client := samson.New("token")
+	client.BaseURL = server.URL
+
+	cfg, err := Load([]byte(data))
+	if err != nil {
+		return nil, err
+	}
+
+	return NewPlan(client, cfg, opts)
+}
+
+func TestPlan_create(t *testing.T) {
+	assert := assert.New(t)
+
+	f := samsontest.New()
+	plan, err := newTestPlan(f, example, Options{})
+	assert.Nil(err)
+	assert.False(plan.Empty())
+	assert.Equal(`+ create environment Production
+    name: "Production"
+    production: true
++ create project example
+    name: "Example"
+    repository_url: "git@github.com:example/example.git"
+    permalink: "example"
+    environment variable RAILS_ENV: "production"
++ create command notify
+    command: "./notify.sh"
++ create command example/deploy
+    command: "bundle exec cap deploy"
++ create stage example/production
+    name: "Production"
+    permalink: "production"
+    confirm: true
+    commands: ["deploy","notify"]
+
+Plan: 5 to create, 0 to update, 0 to delete.
+`, plan.String())
+
+	f.Requests = nil
+	assert.Nil(plan.Apply())
+	assert.Equal([]string{
+		"POST /environments.json",
+		"POST /projects.json",
+		"POST /commands.json",
+		"POST /commands.json",
+		"POST /stages.json",
+	}, f.Requests)
+
+	stage := f.Resources["stages"][105]
+	assert.Equal(float64(102), stage["project_id"])
+	assert.Equal([]interface{}{float64(104), float64(103)}, stage["command_ids"])
+	assert.Len(stage["slack_webhooks_attributes"], 1)
+	assert.Equal(float64(102), f.Resources["commands"][104]["project_id"])
+	assert.Nil(f.Resources["commands"][103]["project_id"])
+
+	plan, err = newTestPlan(f, example, Options{})
+	assert.Nil(err)
+	assert.True(plan.Empty())
+	assert.Equal("No changes.\n", plan.String())
+}
+
+func seed(f *samsontest.Server) {
+	f.Add("environments", `{"id": 1, "name": "Production", "production": "0"}`)
+	f.Add("environments", `{"id": 2, "name": "Staging", "production": "0"}`)
+	f.Add("projects", `{"id": 1, "name": "Old", "permalink": "example", "repository_url": "git@github.com:example/example.git",
+		"created_at": "2018-03-26T11:52:01Z",
+		"environment_variables_attributes": [
+			{"id": 1, "name": "RAILS_ENV", "value": "staging"},
+			{"id": 2, "name": "OLD", "value": "1"}
+		]}`)
+	f.Add("projects", `{"id": 2, "name": "Other", "permalink": "other", "created_at": "2018-03-26T11:52:01Z"}`)
+	f.Add("commands", `{"id": 10, "command": "bundle exec cap deploy", "project_id": "1"}`)
+	f.Add("commands", `{"id": 11, "command": "echo stale", "project_id": "1"}`)
+	f.Add("commands", `{"id": 12, "command": "./notify.sh"}`)
+	f.Add("commands", `{"id": 13, "command": "./unused.sh"}`)
+	f.Add("stages", `{"id": 1, "name": "Production", "permalink": "production", "project_id": 1, "confirm": false,
+		"command_ids": ["11"], "created_at": "2018-03-26T11:52:01Z",
+		"slack_webhooks_attributes": [
+			{"id": 5, "webhook_url": "https://hooks.slack.com/services/T/B/X", "channel": "deploys", "after_deploy": false},
+			{"id": 6, "webhook_url": "https://hooks.slack.com/services/T/B/X", "channel": "random", "after_deploy": true}
+		]}`)
+	f.Add("stages", `{"id": 2, "name": "Staging", "permalink": "staging", "project_id": 1, "created_at": "2018-03-26T11:52:01Z"}`)
+	f.Add("stages", `{"id": 3, "name": "Other", "permalink": "other", "project_id": 2, "created_at": "2018-03-26T11:52:01Z"}`)
+}
+
+func TestPlan_update(t *testing.T) {
+	assert := assert.New(t)
+
+	f := samsontest.New()
+	seed(f)
+
+	plan, err := newTestPlan(f, example, Options{})
+	assert.Nil(err)
+	assert.Equal(`~ update environment Production
+    production: false -> true
+~ update project example
+    name: "Old" -> "Example"
+    environment variable RAILS_ENV: "staging" -> "production"
+    environment variable OLD: "1" -> (none)
+~ update stage example/production
+    confirm: false -> true
+    commands: ["#11"] -> ["deploy","notify"]
+    slack webhook https://hooks.slack.com/services/T/B/X #deploys after_deploy: false -> true
+    slack webhook https://hooks.slack.com/services/T/B/X #random: {"after_deploy":true,"channel":"random","webhook_url":"https://hooks.slack.com/services/T/B/X"} -> (none)
+- delete stage example/staging
+- delete command example/"echo stale"
+
+Plan: 0 to create, 3 to update, 2 to delete.
+`, plan.String())
+
+	f.Requests = nil
+	assert.Nil(plan.Apply())
+	assert.Equal([]string{
+		"PUT /environments/1.json",
+		"PUT /projects/1.json",
+		"PUT /stages/1.json",
+		"DELETE /stages/2.json",
+		"DELETE /commands/11.json",
+	}, f.Requests)
+
+	project := f.Resources["projects"][1]
+	assert.Equal([]interface{}{
+		map[string]interface{}{"id": float64(1), "name": "RAILS_ENV", "value": "production"},
+	}, project["environment_variables_attributes"])
+
+	stage := f.Resources["stages"][1]
+	assert.Equal([]interface{}{float64(10), float64(12)}, stage["command_ids"])
+	assert.Len(stage["slack_webhooks_attributes"], 1)
+
+	plan, err = newTestPlan(f, example, Options{})
+	assert.Nil(err)
+	assert.True(plan.Empty())
+}
+
+func TestPlan_unmanaged(t *testing.T) {
+	assert := assert.New(t)
+
+	f := samsontest.New()
+	seed(f)
+
+	plan, err := newTestPlan(f, `
+projects:
+  - permalink: example
+    name: Old
+    stages:
+      - permalink: production
+        confirm: false
+`, Options{})
+	assert.Nil(err)
+	assert.Equal(`- delete stage example/staging
+
+Plan: 0 to create, 0 to update, 1 to delete.
+`, plan.String())
+
+	plan, err = newTestPlan(f, "projects: [{permalink: example}]", Options{})
+	assert.Nil(err)
+	assert.True(plan.Empty(), plan.String())
+}
+
+func TestPlan_prune(t *testing.T) {
+	assert := assert.New(t)
+
+	f := samsontest.New()
+	seed(f)
+
+	plan, err := newTestPlan(f, example, Options{Prune: true})
+	assert.Nil(err)
+
+	var deletes []string
+	for _, change := range plan.Changes {
+		if change.Action == Delete {
+			deletes = append(deletes, change.Resource+" "+change.Name)
+		}
+	}
+	assert.Equal([]string{
+		"stage example/staging",
+		`command "./unused.sh"`,
+		`command example/"echo stale"`,
+		"project other",
+		"environment Staging",
+	}, deletes)
+
+	assert.Nil(plan.Apply())
+	assert.Len(f.Resources["projects"], 1)
+	assert.Len(f.Resources["environments"], 1)
+	assert.Len(f.Resources["commands"], 2)
+}
+
+func TestPlan_fail(t *testing.T) {
+	assert := assert.New(t)
+
+	f := samsontest.New()
+	f.Fail = "POST /stages.json"
+	plan, err := newTestPlan(f, example, Options{})
+	assert.Nil(err)
+
+	err = plan.Apply()
+	assert.EqualError(err, "create stage example/production: invalid")
+	assert.Len(f.Resources["projects"], 1)
+
+	for _, path := range []string{"GET /environments.json", "GET /commands.json", "GET /projects.json", "GET /stages.json"} {
+		f = samsontest.New()
+		f.Fail = path
+		_, err = newTestPlan(f, example, Options{})
+		assert.EqualError(err, "invalid", path)
+	}
+
+	f = samsontest.New()
+	seed(f)
+	f.Fail = "GET /projects/1.json"
+	_, err = newTestPlan(f, example, Options{})
+	assert.EqualError(err, "invalid")
+}
+
+func TestPlanWrite(t *testing.T) {
+	assert := assert.New(t)
+
+	var buf bytes.Buffer
+	plan := &Plan{}
+	assert.Nil(plan.Write(&buf))
+	assert.Equal("No changes.\n", buf.String())
+}
+
+func ExampleNewPlan() {
+	cfg, err := LoadFile("samson.yml")
+	if err != nil {
+		panic(err)
+	}
+
+	client := samson.New(os.Getenv("SAMSON_TOKEN"))
+	plan, err := NewPlan(client, cfg, Options{})
+	if err != nil {
+		panic(err)
+	}
+
+	plan.Write(os.Stdout)
+	if err := plan.Apply(); err != nil {
+		panic(err)
+	}
+}
+
+func TestDiffSlackWebhooks_duplicates(t *testing.T) {
+	assert := assert.New(t)
+
+	live := []*samson.SlackWebhookAttribute{
+		{ID: samson.Int(1), WebhookURL: samson.String("https://hooks.slack.com/x"), Channel: samson.String("deploys")},
+		{ID: samson.Int(2), WebhookURL: samson.String("https://hooks.slack.com/x"), Channel: samson.String("deploys")},
+	}
+	desired := []*samson.SlackWebhookAttribute{
+		{WebhookURL: samson.String("https://hooks.slack.com/x"), Channel: samson.String("deploys")},
+	}
+
+	changes, attributes := diffSlackWebhooks(desired, live)
+	assert.Len(changes, 1)
+	assert.Equal("slack webhook https://hooks.slack.com/x #deploys (duplicate 2)", changes[0].Field)
+	assert.Len(attributes, 2)
+	assert.Equal(1, *attributes[0].ID)
+	assert.Nil(attributes[0].Destroy)
+	assert.Equal(2, *attributes[1].ID)
+	assert.True(*attributes[1].Destroy)
+
+	_, attributes = diffSlackWebhooks(append(desired, desired[0]), live[:1])
+	assert.Len(attributes, 2)
+	assert.Equal(1, *attributes[0].ID)
+	assert.Nil(attributes[1].ID)
+}
@@ -0,0 +1,169 @@
+package samson
+
+import (
+	"errors"
+	"fmt"
+	"time"
+)
+
+// DeployService service
+type DeployService service
+
+// Deploy statuses
+const (
+	DeployStatusPending    = "pending"
+	DeployStatusRunning    = "running"
+	DeployStatusSucceeded  = "succeeded"
+	DeployStatusFailed     = "failed"
+	DeployStatusErrored    = "errored"
+	DeployStatusCancelling = "cancelling"
+	DeployStatusCancelled  = "cancelled"
+)
+
+var (
+	// ErrSelfApproval is returned when approving a deploy started by the current user
+	ErrSelfApproval = errors.New("deploys can not be approved by the user who started them")
+
+	// ErrNotWaitingForApproval is returned when approving or rejecting a deploy
+	// which is not blocked on approval
+	ErrNotWaitingForApproval = errors.New("deploy is not waiting for approval")
+)
+
+// Deploy model
+type Deploy struct {
+	ID         *int       `json:"id,omitempty"`
+	StageID    *int       `json:"stage_id,omitempty"`
+	ProjectID  *int       `json:"project_id,omitempty"`
+	JobID      *int       `json:"job_id,omitempty"`
+	UserID     *int       `json:"user_id,omitempty"`
+	Reference  *string    `json:"reference,omitempty"`
+	Commit     *string    `json:"commit,omitempty"`
+	Status     *string    `json:"status,omitempty"`
+	Summary    *string    `json:"summary,omitempty"`
+	Production *bool      `json:"production,omitempty"`
+	BuddyID    *int       `json:"buddy_id,omitempty"`
+	StartedAt  *time.Time `json:"started_at,omitempty"`
+	CreatedAt  *time.Time `json:"created_at,omitempty"`
+	UpdatedAt  *time.Time `json:"updated_at,omitempty"`
+}
+
+// IsWaitingForApproval returns whether the deploy is blocked on a buddy approval
+// Only pending production deploys without a buddy need to be approved
+func (d *Deploy) IsWaitingForApproval() bool {
+	return d.Status != nil && *d.Status == DeployStatusPending &&
+		d.Production != nil && *d.Production &&
+		d.BuddyID == nil
+}
+
+// Get returns a single deploy resource
+func (service *DeployService) Get(id int) (*Deploy, *Call, error) {
+	path := fmt.Sprintf("/deploys/%d.json", id)
+	method := "GET"
+
+	call, err := service.s.NewCall(method, path, nil, nil, nil)
+	if err != nil {
+		return nil, call, err
+	}
+
+	var deploy Deploy
+	err = call.Do(&deploy)
+	if err != nil {
+		return nil, call, err
+	}
+
+	return &deploy, call, nil
+}
+
+// ListAwaitingApproval returns the deploys blocked on a buddy approval
+func (service *DeployService) ListAwaitingApproval() ([]*Deploy, *Call, error) {
+	path := "/deploys.json"
+	method := "GET"
+
+	call, err := service.s.NewCall(method, path, map[string]string{"status": DeployStatusPending}, nil, nil)
+	if err != nil {
+		return nil, call, err
+	}
+
+	type response struct {
+		Deploys []*Deploy `json:"deploys,omitempty"`
+	}
+	var res response
+	err = call.Do(&res)
+	if err != nil {
+		return nil, call, err
+	}
+
+	deploys := []*Deploy{}
+	for _, deploy := range res.Deploys {
+		if deploy.IsWaitingForApproval() {
+			deploys = append(deploys, deploy)
+		}
+	}
+
+	return deploys, call, nil
+}
+
+// Approve approves a deploy waiting for approval as the buddy of its deployer
+// ErrSelfApproval is returned for deploys started by the current user, other
+// refusals of the server are returned as ErrorResponse with a 403 status code
+func (service *DeployService) Approve(id int) (*Deploy, *Call, error) {
+	deploy, call, err := service.pending(id)
+	if err != nil {
+		return nil, call, err
+	}
+
+	user, call, err := service.s.Users.Current()
+	if err != nil {
+		return nil, call, err
+	}
+
+	if deploy.UserID != nil && user.ID != nil && *deploy.UserID == *user.ID {
+		return nil, call, ErrSelfApproval
+	}
+
+	path := fmt.Sprintf("/deploys/%d/buddy_check.json", id)
+	method := "POST"
+
+	call, err = service.s.NewCall(method, path, nil, nil, nil)
+	if err != nil {
+		return nil, call, err
+	}
+
+	err = call.Do(&deploy)
+	if err != nil {
+		return nil, call, err
+	}
+
+	return deploy, call, nil
+}
+
+// Reject cancels a deploy waiting for approval
+func (service *DeployService) Reject(id int) (*Call, error) {
+	_, call, err := service.pending(id)
+	if err != nil {
+		return call, err
+	}
+
+	path := fmt.Sprintf("/deploys/%d.json", id)
+	method := "DELETE"
+
+	call, err = service.s.NewCall(method, path, nil, nil, nil)
+	if err != nil {
+		return call, err
+	}
+
+	return call, call.Do(nil)
+}
+
+func (service *DeployService) pending(id int) (*Deploy, *Call, error) {
+	deploy, call, err := service.Get(id)
+	if err != nil {
+		return nil, call, err
+	}
+
+	if !deploy.IsWaitingForApproval() {
+		return nil, call, ErrNotWaitingForApproval
+	}
+
+	return deploy, call, nil
+}
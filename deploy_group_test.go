@@ -0,0 +1,238 @@
+package samson
+
+import (
+	"encoding/json"
+	"fmt"
+	"log"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func ExampleDeployGroupService_List() {
+	client := New("token")
+
+	deployGroups, _, err := client.DeployGroups.List()
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	for _, deployGroup := range deployGroups {
+		fmt.Println(*deployGroup.ID, *deployGroup.Name)
+	}
+}
+
+func ExampleDeployGroupService_Upsert() {
+	client := New("token")
+
+	deployGroup := &DeployGroup{
+		Name:          String("Pod 3"),
+		EnvironmentID: Int(1),
+		ClusterDeployGroup: &ClusterDeployGroup{
+			KubernetesClusterID: Int(1),
+			Namespace:           String("pod3"),
+		},
+	}
+
+	deployGroup, _, err := client.DeployGroups.Upsert(deployGroup)
+	if err != nil {
+		log.Fatal(err)
+	}
+}
+
+func TestDeployGroupServiceList(t *testing.T) {
+	var err error
+	assert := assert.New(t)
+
+	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal("GET", r.Method)
+		assert.Equal("/deploy_groups.json", r.URL.Path)
+		checkHeaders(r, assert)
+
+		w.WriteHeader(200)
+		fmt.Fprintln(w, readTestData("deploy_groups.json"))
+	})
+
+	server := httptest.NewServer(handler)
+	defer server.Close()
+
+	client = New(token)
+	client.BaseURL = server.URL
+
+	deployGroups, call, err := client.DeployGroups.List()
+	assert.Nil(err)
+	assert.Equal(2, len(deployGroups))
+	assert.Equal("pod2", *deployGroups[1].ClusterDeployGroup.Namespace)
+	assert.IsType(&Call{}, call)
+}
+
+func TestDeployGroupServiceList_fail(t *testing.T) {
+	var err error
+	assert := assert.New(t)
+
+	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(200)
+		fmt.Fprintln(w, "malformed json response")
+	})
+
+	server := httptest.NewServer(handler)
+	defer server.Close()
+
+	client = New(token)
+	client.BaseURL = server.URL
+
+	deployGroups, call, err := client.DeployGroups.List()
+	assert.NotNil(err)
+	assert.IsType(&Call{}, call)
+	assert.Nil(deployGroups)
+}
+
+func TestDeployGroupServiceGet(t *testing.T) {
+	var err error
+	assert := assert.New(t)
+
+	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal("GET", r.Method)
+		assert.Equal("/deploy_groups/1.json", r.URL.Path)
+		checkHeaders(r, assert)
+
+		w.WriteHeader(200)
+		fmt.Fprintln(w, readTestData("deploy_group.json"))
+	})
+
+	server := httptest.NewServer(handler)
+	defer server.Close()
+
+	client = New(token)
+	client.BaseURL = server.URL
+
+	deployGroup, call, err := client.DeployGroups.Get(1)
+	assert.Nil(err)
+	assert.Equal(1, *deployGroup.ID)
+	assert.Equal(1, *deployGroup.EnvironmentID)
+	assert.Equal(1, *deployGroup.ClusterDeployGroup.KubernetesClusterID)
+	assert.IsType(&Call{}, call)
+}
+
+func TestDeployGroupServiceGet_fail(t *testing.T) {
+	var err error
+	assert := assert.New(t)
+
+	client = New(token)
+	client.BaseURL = "^http://localhost"
+
+	deployGroup, call, err := client.DeployGroups.Get(1)
+	assert.NotNil(err)
+	assert.IsType(&Call{}, call)
+	assert.Nil(deployGroup)
+}
+
+func TestDeployGroupServiceCreate(t *testing.T) {
+	var err error
+	assert := assert.New(t)
+
+	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal("POST", r.Method)
+		assert.Equal("/deploy_groups.json", r.URL.Path)
+		checkHeaders(r, assert)
+
+		var payload map[string]interface{}
+		err := json.NewDecoder(r.Body).Decode(&payload)
+		assert.Nil(err)
+		assert.Equal("Pod 1", payload["name"])
+		assert.Equal(float64(1), payload["environment_id"])
+
+		w.WriteHeader(200)
+		fmt.Fprintln(w, readTestData("deploy_group.json"))
+	})
+
+	server := httptest.NewServer(handler)
+	defer server.Close()
+
+	client = New(token)
+	client.BaseURL = server.URL
+
+	deployGroup := &DeployGroup{
+		Name:          String("Pod 1"),
+		EnvironmentID: Int(1),
+	}
+
+	deployGroup, call, err := client.DeployGroups.Upsert(deployGroup)
+	assert.Nil(err)
+	assert.IsType(&Call{}, call)
+	assert.Equal(1, *deployGroup.ID)
+}
+
+func TestDeployGroupServiceUpdate(t *testing.T) {
+	var err error
+	assert := assert.New(t)
+
+	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal("PUT", r.Method)
+		assert.Equal("/deploy_groups/1.json", r.URL.Path)
+		checkHeaders(r, assert)
+
+		var payload map[string]interface{}
+		err := json.NewDecoder(r.Body).Decode(&payload)
+		assert.Nil(err)
+		assert.Equal("Pod 1", payload["name"])
+
+		w.WriteHeader(200)
+		fmt.Fprintln(w, readTestData("deploy_group.json"))
+	})
+
+	server := httptest.NewServer(handler)
+	defer server.Close()
+
+	client = New(token)
+	client.BaseURL = server.URL
+
+	deployGroup := &DeployGroup{
+		ID:   Int(1),
+		Name: String("Pod 1"),
+	}
+
+	deployGroup, call, err := client.DeployGroups.Upsert(deployGroup)
+	assert.Nil(err)
+	assert.IsType(&Call{}, call)
+	assert.NotNil(deployGroup.CreatedAt)
+}
+
+func TestDeployGroupServiceUpsert_fail(t *testing.T) {
+	var err error
+	assert := assert.New(t)
+
+	client = New(token)
+	client.BaseURL = "^http://localhost"
+
+	deployGroup, call, err := client.DeployGroups.Upsert(&DeployGroup{})
+	assert.NotNil(err)
+	assert.IsType(&Call{}, call)
+	assert.Nil(deployGroup)
+}
+
+func TestDeployGroupServiceDelete(t *testing.T) {
+	var err error
+	assert := assert.New(t)
+
+	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal("DELETE", r.Method)
+		assert.Equal("/deploy_groups/1.json", r.URL.Path)
+		checkHeaders(r, assert)
+
+		w.WriteHeader(200)
+		fmt.Fprintln(w, "")
+	})
+
+	server := httptest.NewServer(handler)
+	defer server.Close()
+
+	client = New(token)
+	client.BaseURL = server.URL
+
+	call, err := client.DeployGroups.Delete(1)
+	assert.Nil(err)
+	assert.IsType(&Call{}, call)
+}
@@ -0,0 +1,282 @@
+package samson
+
+import (
+	"fmt"
+	"log"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func ExampleDeployService_Approve() {
+	client := New("token")
+
+	deploys, _, err := client.Deploys.ListAwaitingApproval()
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	for _, deploy := range deploys {
+		_, _, err := client.Deploys.Approve(*deploy.ID)
+		if err == ErrSelfApproval {
+			continue
+		}
+		if err != nil {
+			log.Fatal(err)
+		}
+
+		fmt.Println("approved", *deploy.Summary)
+	}
+}
+
+func deployTestHandler(assert *assert.Assertions, deployFixture string, requests *[]string) http.HandlerFunc {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		checkHeaders(r, assert)
+		*requests = append(*requests, r.Method+" "+r.URL.Path)
+
+		switch r.Method + " " + r.URL.Path {
+		case "GET /deploys/7.json":
+			fmt.Fprintln(w, readTestData(deployFixture))
+		case "GET /users/current.json":
+			fmt.Fprintln(w, readTestData("user.json"))
+		case "POST /deploys/7/buddy_check.json":
+			fmt.Fprintln(w, readTestData("deploy_approved.json"))
+		case "DELETE /deploys/7.json":
+			fmt.Fprintln(w, "")
+		default:
+			w.WriteHeader(404)
+			fmt.Fprintln(w, readTestData("error-notfound.json"))
+		}
+	})
+}
+
+func TestDeployIsWaitingForApproval(t *testing.T) {
+	assert := assert.New(t)
+
+	deploy := &Deploy{
+		Status:     String(DeployStatusPending),
+		Production: Bool(true),
+	}
+	assert.True(deploy.IsWaitingForApproval())
+
+	deploy.BuddyID = Int(2)
+	assert.False(deploy.IsWaitingForApproval())
+
+	deploy.BuddyID = nil
+	deploy.Production = Bool(false)
+	assert.False(deploy.IsWaitingForApproval())
+
+	deploy.Production = Bool(true)
+	deploy.Status = String(DeployStatusRunning)
+	assert.False(deploy.IsWaitingForApproval())
+
+	assert.False((&Deploy{}).IsWaitingForApproval())
+}
+
+func TestDeployServiceGet(t *testing.T) {
+	var err error
+	assert := assert.New(t)
+
+	requests := []string{}
+	server := httptest.NewServer(deployTestHandler(assert, "deploy.json", &requests))
+	defer server.Close()
+
+	client = New(token)
+	client.BaseURL = server.URL
+
+	deploy, call, err := client.Deploys.Get(7)
+	assert.Nil(err)
+	assert.IsType(&Call{}, call)
+	assert.Equal(1, *deploy.UserID)
+	assert.True(deploy.IsWaitingForApproval())
+}
+
+func TestDeployServiceGet_fail(t *testing.T) {
+	var err error
+	assert := assert.New(t)
+
+	client = New(token)
+	client.BaseURL = "^http://localhost"
+
+	deploy, call, err := client.Deploys.Get(7)
+	assert.NotNil(err)
+	assert.IsType(&Call{}, call)
+	assert.Nil(deploy)
+}
+
+func TestDeployServiceListAwaitingApproval(t *testing.T) {
+	var err error
+	assert := assert.New(t)
+
+	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal("GET", r.Method)
+		assert.Equal("/deploys.json", r.URL.Path)
+		assert.Equal("pending", r.URL.Query().Get("status"))
+		checkHeaders(r, assert)
+
+		w.WriteHeader(200)
+		fmt.Fprintln(w, readTestData("deploys_pending.json"))
+	})
+
+	server := httptest.NewServer(handler)
+	defer server.Close()
+
+	client = New(token)
+	client.BaseURL = server.URL
+
+	deploys, call, err := client.Deploys.ListAwaitingApproval()
+	assert.Nil(err)
+	assert.IsType(&Call{}, call)
+	assert.Equal(1, len(deploys))
+	assert.Equal(7, *deploys[0].ID)
+}
+
+func TestDeployServiceListAwaitingApproval_fail(t *testing.T) {
+	var err error
+	assert := assert.New(t)
+
+	client = New(token)
+	client.BaseURL = "^http://localhost"
+
+	deploys, call, err := client.Deploys.ListAwaitingApproval()
+	assert.NotNil(err)
+	assert.IsType(&Call{}, call)
+	assert.Nil(deploys)
+}
+
+func TestDeployServiceApprove(t *testing.T) {
+	var err error
+	assert := assert.New(t)
+
+	requests := []string{}
+	server := httptest.NewServer(deployTestHandler(assert, "deploy.json", &requests))
+	defer server.Close()
+
+	client = New(token)
+	client.BaseURL = server.URL
+
+	deploy, call, err := client.Deploys.Approve(7)
+	assert.Nil(err)
+	assert.IsType(&Call{}, call)
+	assert.Equal(2, *deploy.BuddyID)
+	assert.Equal(DeployStatusRunning, *deploy.Status)
+	assert.Equal([]string{
+		"GET /deploys/7.json",
+		"GET /users/current.json",
+		"POST /deploys/7/buddy_check.json",
+	}, requests)
+}
+
+func TestDeployServiceApprove_self(t *testing.T) {
+	var err error
+	assert := assert.New(t)
+
+	requests := []string{}
+	server := httptest.NewServer(deployTestHandler(assert, "deploy_own.json", &requests))
+	defer server.Close()
+
+	client = New(token)
+	client.BaseURL = server.URL
+
+	deploy, call, err := client.Deploys.Approve(7)
+	assert.Equal(ErrSelfApproval, err)
+	assert.IsType(&Call{}, call)
+	assert.Nil(deploy)
+	assert.Equal(2, len(requests))
+}
+
+func TestDeployServiceApprove_notpending(t *testing.T) {
+	var err error
+	assert := assert.New(t)
+
+	requests := []string{}
+	server := httptest.NewServer(deployTestHandler(assert, "deploy_approved.json", &requests))
+	defer server.Close()
+
+	client = New(token)
+	client.BaseURL = server.URL
+
+	deploy, _, err := client.Deploys.Approve(7)
+	assert.Equal(ErrNotWaitingForApproval, err)
+	assert.Nil(deploy)
+}
+
+func TestDeployServiceApprove_forbidden(t *testing.T) {
+	var err error
+	assert := assert.New(t)
+
+	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/deploys/7.json":
+			fmt.Fprintln(w, readTestData("deploy.json"))
+		case "/users/current.json":
+			fmt.Fprintln(w, readTestData("user.json"))
+		default:
+			w.WriteHeader(403)
+			fmt.Fprintln(w, `{"message": "You are not allowed to approve deploys of this stage"}`)
+		}
+	})
+
+	server := httptest.NewServer(handler)
+	defer server.Close()
+
+	client = New(token)
+	client.BaseURL = server.URL
+
+	deploy, _, err := client.Deploys.Approve(7)
+	assert.NotNil(err)
+	assert.Nil(deploy)
+	assert.IsType(ErrorResponse{}, err)
+	assert.Equal(403, err.(ErrorResponse).StatusCode)
+}
+
+func TestDeployServiceApprove_fail(t *testing.T) {
+	var err error
+	assert := assert.New(t)
+
+	requests := []string{}
+	server := httptest.NewServer(deployTestHandler(assert, "deploy.json", &requests))
+	defer server.Close()
+
+	client = New(token)
+	client.BaseURL = server.URL
+
+	deploy, _, err := client.Deploys.Approve(8)
+	assert.NotNil(err)
+	assert.Nil(deploy)
+}
+
+func TestDeployServiceReject(t *testing.T) {
+	var err error
+	assert := assert.New(t)
+
+	requests := []string{}
+	server := httptest.NewServer(deployTestHandler(assert, "deploy.json", &requests))
+	defer server.Close()
+
+	client = New(token)
+	client.BaseURL = server.URL
+
+	call, err := client.Deploys.Reject(7)
+	assert.Nil(err)
+	assert.IsType(&Call{}, call)
+	assert.Equal([]string{"GET /deploys/7.json", "DELETE /deploys/7.json"}, requests)
+}
+
+func TestDeployServiceReject_notpending(t *testing.T) {
+	var err error
+	assert := assert.New(t)
+
+	requests := []string{}
+	server := httptest.NewServer(deployTestHandler(assert, "deploy_approved.json", &requests))
+	defer server.Close()
+
+	client = New(token)
+	client.BaseURL = server.URL
+
+	_, err = client.Deploys.Reject(7)
+	assert.Equal(ErrNotWaitingForApproval, err)
+	assert.Equal(1, len(requests))
+}
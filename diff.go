@@ -0,0 +1,284 @@
+package samson
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"strings"
+	"time"
+)
+
+// ServerFields are the json names of model fields set by samson, they are
+// ignored when diffing models and not meant to be sent
+var ServerFields = map[string]bool{
+	"id":               true,
+	"token":            true,
+	"star_count":       true,
+	"last_deployed_at": true,
+	"last_deployed_by": true,
+	"last_deploy_url":  true,
+	"created_at":       true,
+	"updated_at":       true,
+	"deleted_at":       true,
+}
+
+// DiffProject returns the fields differing between two projects
+// Environment variables are matched by name and scope, see DiffEnvironmentVariable
+func DiffProject(a, b *Project) []FieldChange {
+	return diffModels(a, b)
+}
+
+// DiffStage returns the fields differing between two stages
+// Slack webhooks are matched by webhook url and channel, see DiffSlackWebhookAttribute
+func DiffStage(a, b *Stage) []FieldChange {
+	return diffModels(a, b)
+}
+
+// DiffCommand returns the fields differing between two commands
+func DiffCommand(a, b *Command) []FieldChange {
+	return diffModels(a, b)
+}
+
+// DiffEnvironment returns the fields differing between two environments
+func DiffEnvironment(a, b *Environment) []FieldChange {
+	return diffModels(a, b)
+}
+
+// DiffEnvironmentVariable returns the fields differing between two environment variables
+func DiffEnvironmentVariable(a, b *EnvironmentVariable) []FieldChange {
+	return diffModels(a, b)
+}
+
+// DiffSlackWebhookAttribute returns the fields differing between two slack webhooks
+func DiffSlackWebhookAttribute(a, b *SlackWebhookAttribute) []FieldChange {
+	return diffModels(a, b)
+}
+
+// OmitFields returns the changes without those of the given fields and
+// the models nested in them
+func OmitFields(changes []FieldChange, fields ...string) []FieldChange {
+	var kept []FieldChange
+	for _, change := range changes {
+		omit := false
+		for _, field := range fields {
+			if change.Field == field || strings.HasPrefix(change.Field, field+".") || strings.HasPrefix(change.Field, field+"[") {
+				omit = true
+				break
+			}
+		}
+		if !omit {
+			kept = append(kept, change)
+		}
+	}
+
+	return kept
+}
+
+// keyedModel is implemented by nested models matched by a key rather than
+// by their position when diffing
+type keyedModel interface {
+	diffKey() string
+}
+
+var keyedModelType = reflect.TypeOf((*keyedModel)(nil)).Elem()
+
+func (variable *EnvironmentVariable) diffKey() string {
+	key := stringValue(variable.Name)
+	if scope := stringValue(variable.ScopeTypeAndID); scope != "" {
+		key += " (" + scope + ")"
+	}
+
+	return key
+}
+
+func (webhook *SlackWebhookAttribute) diffKey() string {
+	key := stringValue(webhook.WebhookURL)
+	if channel := stringValue(webhook.Channel); channel != "" {
+		key += " #" + channel
+	}
+
+	return key
+}
+
+// diffModels compares two pointers to the same model type, a nil model
+// is compared as an empty one
+func diffModels(a, b interface{}) []FieldChange {
+	return diffStruct("", reflect.ValueOf(a).Elem(), reflect.ValueOf(b).Elem(), reflect.TypeOf(a).Elem(), nil)
+}
+
+// diffStruct appends the changes between two structs of type t, the
+// values are invalid for nil models. Field paths are json names joined by
+// dots with the key or index of nested models in brackets like
+// environment_variables_attributes[RAILS_ENV (Environment-1)].value, values
+// are in their json form.
+func diffStruct(prefix string, a, b reflect.Value, t reflect.Type, changes []FieldChange) []FieldChange {
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		name := strings.Split(field.Tag.Get("json"), ",")[0]
+		if field.PkgPath != "" || name == "" || name == "-" || ServerFields[name] {
+			continue
+		}
+
+		var fa, fb reflect.Value
+		if a.IsValid() {
+			fa = a.Field(i)
+		}
+		if b.IsValid() {
+			fb = b.Field(i)
+		}
+
+		if model := nestedModel(field.Type); model != nil {
+			changes = diffNested(prefix+name, fa, fb, model, changes)
+			continue
+		}
+
+		before := jsonValue(fa)
+		after := jsonValue(fb)
+		if !reflect.DeepEqual(before, after) {
+			changes = append(changes, FieldChange{Field: prefix + name, Before: before, After: after})
+		}
+	}
+
+	return changes
+}
+
+// diffNested compares slices of nested models by key, or element by
+// element for models without one
+func diffNested(path string, a, b reflect.Value, t reflect.Type, changes []FieldChange) []FieldChange {
+	if reflect.PtrTo(t).Implements(keyedModelType) {
+		return diffKeyed(path, a, b, t, changes)
+	}
+
+	length := func(v reflect.Value) int {
+		if !v.IsValid() {
+			return 0
+		}
+		return v.Len()
+	}
+
+	for i := 0; i < length(a) || i < length(b); i++ {
+		var ea, eb reflect.Value
+		if i < length(a) {
+			ea = reflect.Indirect(a.Index(i))
+		}
+		if i < length(b) {
+			eb = reflect.Indirect(b.Index(i))
+		}
+
+		changes = diffElement(fmt.Sprintf("%s[%d]", path, i), ea, eb, t, changes)
+	}
+
+	return changes
+}
+
+// diffKeyed compares slices of nested models matched by their key, in the
+// order of a followed by the models only b has. Models sharing a key are
+// matched in order, the later ones named like RAILS_ENV (duplicate 2), so
+// duplicates show up rather than hiding each other.
+func diffKeyed(path string, a, b reflect.Value, t reflect.Type, changes []FieldChange) []FieldChange {
+	var keys []string
+	index := func(v reflect.Value) map[string]reflect.Value {
+		elements := map[string]reflect.Value{}
+		if !v.IsValid() {
+			return elements
+		}
+		for i := 0; i < v.Len(); i++ {
+			element := reflect.Indirect(v.Index(i))
+			if !element.IsValid() {
+				continue
+			}
+			key := element.Addr().Interface().(keyedModel).diffKey()
+			for n := 2; ; n++ {
+				if _, ok := elements[key]; !ok {
+					break
+				}
+				key = fmt.Sprintf("%s (duplicate %d)", element.Addr().Interface().(keyedModel).diffKey(), n)
+			}
+			elements[key] = element
+			keys = append(keys, key)
+		}
+		return elements
+	}
+
+	ea := index(a)
+	eb := index(b)
+	seen := map[string]bool{}
+	for _, key := range keys {
+		if seen[key] {
+			continue
+		}
+		seen[key] = true
+
+		changes = diffElement(fmt.Sprintf("%s[%s]", path, key), ea[key], eb[key], t, changes)
+	}
+
+	return changes
+}
+
+// diffElement compares two nested models, models only one side has are
+// reported as a whole
+func diffElement(path string, a, b reflect.Value, t reflect.Type, changes []FieldChange) []FieldChange {
+	if a.IsValid() && b.IsValid() {
+		return diffStruct(path+".", a, b, t, changes)
+	}
+
+	before := modelValue(a)
+	after := modelValue(b)
+	if !reflect.DeepEqual(before, after) {
+		changes = append(changes, FieldChange{Field: path, Before: before, After: after})
+	}
+
+	return changes
+}
+
+// nestedModel returns the struct type of slices of nested models
+func nestedModel(t reflect.Type) reflect.Type {
+	if t.Kind() != reflect.Slice {
+		return nil
+	}
+
+	elem := t.Elem()
+	if elem.Kind() == reflect.Ptr {
+		elem = elem.Elem()
+	}
+	if elem.Kind() != reflect.Struct || elem == reflect.TypeOf(time.Time{}) {
+		return nil
+	}
+
+	return elem
+}
+
+// jsonValue returns the json form of a field, nil when unset or, like
+// omitempty, an empty slice
+func jsonValue(v reflect.Value) interface{} {
+	if !v.IsValid() {
+		return nil
+	}
+	if (v.Kind() == reflect.Ptr && v.IsNil()) || (v.Kind() == reflect.Slice && v.Len() == 0) {
+		return nil
+	}
+
+	data, err := json.Marshal(v.Interface())
+	if err != nil {
+		return nil
+	}
+
+	var plain interface{}
+	json.Unmarshal(data, &plain)
+
+	return plain
+}
+
+// modelValue returns the json form of a nested model without server fields
+func modelValue(v reflect.Value) interface{} {
+	plain, ok := jsonValue(v).(map[string]interface{})
+	if !ok {
+		return nil
+	}
+
+	for field := range ServerFields {
+		delete(plain, field)
+	}
+
+	return plain
+}
@@ -0,0 +1,168 @@
+package samson
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDiffStage(t *testing.T) {
+	assert := assert.New(t)
+
+	now := time.Now()
+	a := &Stage{
+		ID:         Int(1),
+		Name:       String("Production"),
+		Confirm:    Bool(true),
+		CommandIds: []*int{Int(1), Int(2)},
+		CreatedAt:  &now,
+		SlackWebhookAtrributes: []*SlackWebhookAttribute{
+			{ID: Int(5), WebhookURL: String("https://hooks.slack.com/x"), Channel: String("deploys")},
+		},
+	}
+	b := &Stage{
+		ID:         Int(2),
+		Name:       String("Production"),
+		Confirm:    Bool(false),
+		CommandIds: []*int{Int(2), Int(1)},
+		Kubernetes: Bool(true),
+		SlackWebhookAtrributes: []*SlackWebhookAttribute{
+			{ID: Int(6), WebhookURL: String("https://hooks.slack.com/x"), Channel: String("releases")},
+			{ID: Int(7), WebhookURL: String("https://hooks.slack.com/y")},
+		},
+	}
+
+	assert.Equal([]FieldChange{
+		{Field: "command_ids", Before: []interface{}{1.0, 2.0}, After: []interface{}{2.0, 1.0}},
+		{Field: "confirm", Before: true, After: false},
+		{Field: "kubernetes", Before: nil, After: true},
+		{Field: "slack_webhooks_attributes[https://hooks.slack.com/x #deploys]", Before: map[string]interface{}{"webhook_url": "https://hooks.slack.com/x", "channel": "deploys"}, After: nil},
+		{Field: "slack_webhooks_attributes[https://hooks.slack.com/x #releases]", Before: nil, After: map[string]interface{}{"webhook_url": "https://hooks.slack.com/x", "channel": "releases"}},
+		{Field: "slack_webhooks_attributes[https://hooks.slack.com/y]", Before: nil, After: map[string]interface{}{"webhook_url": "https://hooks.slack.com/y"}},
+	}, DiffStage(a, b))
+
+	assert.Empty(DiffStage(a, a))
+	assert.Empty(DiffStage(&Stage{ID: Int(1)}, &Stage{ID: Int(2), UpdatedAt: &now}))
+}
+
+func TestDiffProject(t *testing.T) {
+	assert := assert.New(t)
+
+	a := &Project{
+		Name:  String("Example"),
+		Token: String("a"),
+		EnvironmentVariableAttributes: []*EnvironmentVariable{
+			{ID: Int(1), Name: String("RAILS_ENV"), Value: String("production")},
+			{ID: Int(2), Name: String("REGION"), Value: String("eu")},
+		},
+	}
+	b := &Project{
+		Name:  String("Renamed"),
+		Token: String("b"),
+		EnvironmentVariableAttributes: []*EnvironmentVariable{
+			{ID: Int(3), Name: String("RAILS_ENV"), Value: String("staging")},
+		},
+	}
+
+	assert.Equal([]FieldChange{
+		{Field: "name", Before: "Example", After: "Renamed"},
+		{Field: "environment_variables_attributes[RAILS_ENV].value", Before: "production", After: "staging"},
+		{Field: "environment_variables_attributes[REGION]", Before: map[string]interface{}{"name": "REGION", "value": "eu"}, After: nil},
+	}, DiffProject(a, b))
+}
+
+func TestDiffProjectMatchesVariablesByScope(t *testing.T) {
+	assert := assert.New(t)
+
+	a := &Project{
+		EnvironmentVariableAttributes: []*EnvironmentVariable{
+			{ID: Int(1), Name: String("RAILS_ENV"), Value: String("production"), ScopeTypeAndID: String("Environment-1")},
+			{ID: Int(2), Name: String("RAILS_ENV"), Value: String("staging"), ScopeTypeAndID: String("Environment-2")},
+		},
+	}
+	b := &Project{
+		EnvironmentVariableAttributes: []*EnvironmentVariable{
+			{ID: Int(4), Name: String("RAILS_ENV"), Value: String("staging"), ScopeTypeAndID: String("Environment-2")},
+			{ID: Int(3), Name: String("RAILS_ENV"), Value: String("prod"), ScopeTypeAndID: String("Environment-1")},
+		},
+	}
+
+	assert.Equal([]FieldChange{
+		{Field: "environment_variables_attributes[RAILS_ENV (Environment-1)].value", Before: "production", After: "prod"},
+	}, DiffProject(a, b))
+
+	b.EnvironmentVariableAttributes[1].Value = String("production")
+	assert.Empty(DiffProject(a, b))
+}
+
+func TestDiffEmptySlices(t *testing.T) {
+	assert := assert.New(t)
+
+	assert.Empty(DiffStage(&Stage{CommandIds: []*int{}, NextStageIds: []*int{}}, &Stage{}))
+	assert.Empty(DiffProject(&Project{}, &Project{EnvironmentVariableGroupIds: []*int{}, EnvironmentVariableAttributes: []*EnvironmentVariable{}}))
+	assert.Equal([]FieldChange{
+		{Field: "command_ids", Before: []interface{}{1.0}, After: nil},
+	}, DiffStage(&Stage{CommandIds: []*int{Int(1)}}, &Stage{CommandIds: []*int{}}))
+}
+
+func TestDiffDuplicateKeys(t *testing.T) {
+	assert := assert.New(t)
+
+	a := &Project{
+		EnvironmentVariableAttributes: []*EnvironmentVariable{
+			{Name: String("RAILS_ENV"), Value: String("production")},
+		},
+	}
+	b := &Project{
+		EnvironmentVariableAttributes: []*EnvironmentVariable{
+			{Name: String("RAILS_ENV"), Value: String("production")},
+			{Name: String("RAILS_ENV"), Value: String("staging")},
+		},
+	}
+	assert.Equal([]FieldChange{
+		{Field: "environment_variables_attributes[RAILS_ENV (duplicate 2)]", Before: nil, After: map[string]interface{}{"name": "RAILS_ENV", "value": "staging"}},
+	}, DiffProject(a, b))
+
+	webhooks := []*SlackWebhookAttribute{
+		{WebhookURL: String("https://hooks.slack.com/x"), Channel: String("deploys"), AfterDeploy: Bool(true)},
+		{WebhookURL: String("https://hooks.slack.com/x"), Channel: String("deploys")},
+	}
+	assert.Equal([]FieldChange{
+		{Field: "slack_webhooks_attributes[https://hooks.slack.com/x #deploys].after_deploy", Before: true, After: nil},
+		{Field: "slack_webhooks_attributes[https://hooks.slack.com/x #deploys (duplicate 2)]", Before: map[string]interface{}{"webhook_url": "https://hooks.slack.com/x", "channel": "deploys"}, After: nil},
+	}, DiffStage(&Stage{SlackWebhookAtrributes: webhooks}, &Stage{SlackWebhookAtrributes: webhooks[1:]}))
+}
+
+func TestOmitFields(t *testing.T) {
+	assert := assert.New(t)
+
+	changes := []FieldChange{
+		{Field: "name"},
+		{Field: "command_ids"},
+		{Field: "slack_webhooks_attributes[https://hooks.slack.com/x].channel"},
+		{Field: "slack_webhooks_attributes_count"},
+	}
+	assert.Equal([]FieldChange{
+		{Field: "name"},
+		{Field: "slack_webhooks_attributes_count"},
+	}, OmitFields(changes, "command_ids", "slack_webhooks_attributes"))
+	assert.Equal(changes, OmitFields(changes))
+}
+
+func TestDiffNil(t *testing.T) {
+	assert := assert.New(t)
+
+	assert.Empty(DiffCommand(nil, nil))
+	assert.Equal([]FieldChange{
+		{Field: "command", Before: nil, After: "ls"},
+		{Field: "project_id", Before: nil, After: 1.0},
+	}, DiffCommand(nil, &Command{ID: Int(1), Command: String("ls"), ProjectID: Int(1)}))
+	assert.Equal([]FieldChange{
+		{Field: "production", Before: true, After: nil},
+	}, DiffEnvironment(&Environment{Production: Bool(true)}, &Environment{}))
+	assert.Equal([]FieldChange{
+		{Field: "scope_type_and_id", Before: "Environment-1", After: "Environment-2"},
+	}, DiffEnvironmentVariable(&EnvironmentVariable{ScopeTypeAndID: String("Environment-1")}, &EnvironmentVariable{ScopeTypeAndID: String("Environment-2")}))
+	assert.Empty(DiffSlackWebhookAttribute(&SlackWebhookAttribute{ID: Int(1)}, &SlackWebhookAttribute{}))
+}
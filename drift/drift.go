@@ -0,0 +1,407 @@
+// Package drift compares two samson instances, or an instance and a
+// snapshot exported by the backup package, and reports the projects,
+// stages, commands and environments that differ.
+//
+// Resources are matched by permalink, environments without one by name
+// and commands by their text, so ids may differ between the two sides.
+// References between resources are compared by what they point to:
+// stage commands by command text, next and template stages and deploy
+// groups by permalink. Environment variable groups aren't archived and are
+// compared by id.
+package drift
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+
+	samson "github.com/tolgaakyuz/samson-go"
+	"github.com/tolgaakyuz/samson-go/backup"
+)
+
+// Kind is how a resource differs
+type Kind string
+
+// Kinds
+const (
+	Added   Kind = "added"
+	Removed Kind = "removed"
+	Changed Kind = "changed"
+)
+
+var kindSymbols = map[Kind]string{
+	Added:   "+",
+	Removed: "-",
+	Changed: "~",
+}
+
+// Difference is a resource which differs between the two sides
+// Fields are set for changed resources, Before is the first side
+type Difference struct {
+	Kind     Kind
+	Resource string
+	Name     string
+	Fields   []samson.FieldChange
+}
+
+// Report lists the differences ordered by resource and name
+type Report struct {
+	Differences []Difference
+}
+
+// Live snapshots a live instance for comparison
+func Live(client *samson.Samson) (*backup.Archive, error) {
+	return backup.Export(client)
+}
+
+// Snapshot reads an archive written by the backup package
+func Snapshot(path string) (*backup.Archive, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+
+	return backup.Read(file)
+}
+
+// Compare reports how b differs from a
+func Compare(a, b *backup.Archive) *Report {
+	left := newIndex(a)
+	right := newIndex(b)
+
+	report := &Report{}
+	for _, resource := range []string{"environment", "command", "project", "stage"} {
+		report.compare(resource, left.resources[resource], right.resources[resource])
+	}
+
+	return report
+}
+
+func (report *Report) compare(kind string, a, b map[string]*resource) {
+	names := map[string]bool{}
+	for name := range a {
+		names[name] = true
+	}
+	for name := range b {
+		names[name] = true
+	}
+	sorted := make([]string, 0, len(names))
+	for name := range names {
+		sorted = append(sorted, name)
+	}
+	sort.Strings(sorted)
+
+	for _, name := range sorted {
+		before, inA := a[name]
+		after, inB := b[name]
+		switch {
+		case !inA:
+			report.Differences = append(report.Differences, Difference{Kind: Added, Resource: kind, Name: name})
+		case !inB:
+			report.Differences = append(report.Differences, Difference{Kind: Removed, Resource: kind, Name: name})
+		default:
+			fields := before.changes(after)
+			if len(fields) > 0 {
+				report.Differences = append(report.Differences, Difference{Kind: Changed, Resource: kind, Name: name, Fields: fields})
+			}
+		}
+	}
+}
+
+// changes compares the models with the samson diff functions, leaving out
+// the ids they reference other resources by, followed by the references
+// resolved to what they point to
+func (r *resource) changes(other *resource) []samson.FieldChange {
+	var changes []samson.FieldChange
+	switch model := r.model.(type) {
+	case *samson.Environment:
+		changes = samson.DiffEnvironment(model, other.model.(*samson.Environment))
+	case *samson.Project:
+		changes = samson.OmitFields(samson.DiffProject(model, other.model.(*samson.Project)), projectReferences...)
+	case *samson.Stage:
+		changes = samson.OmitFields(samson.DiffStage(model, other.model.(*samson.Stage)), stageReferences...)
+	}
+
+	keys := map[string]bool{}
+	for key := range r.references {
+		keys[key] = true
+	}
+	for key := range other.references {
+		keys[key] = true
+	}
+	for key := range keys {
+		before := r.references[key]
+		after := other.references[key]
+		if !reflect.DeepEqual(before, after) {
+			changes = append(changes, samson.FieldChange{Field: key, Before: before, After: after})
+		}
+	}
+
+	sort.SliceStable(changes, func(i, j int) bool {
+		return changes[i].Field < changes[j].Field
+	})
+
+	return changes
+}
+
+// Empty returns whether both sides match
+func (report *Report) Empty() bool {
+	return len(report.Differences) == 0
+}
+
+// Write prints the report in a human readable form
+func (report *Report) Write(w io.Writer) error {
+	if report.Empty() {
+		_, err := fmt.Fprintln(w, "No differences.")
+		return err
+	}
+
+	for _, difference := range report.Differences {
+		fmt.Fprintf(w, "%s %s %s %s\n", kindSymbols[difference.Kind], difference.Resource, difference.Name, difference.Kind)
+		for _, field := range difference.Fields {
+			fmt.Fprintf(w, "    %s: %s -> %s\n", field.Field, formatValue(field.Before), formatValue(field.After))
+		}
+	}
+
+	_, err := fmt.Fprintf(w, "\n%d differences.\n", len(report.Differences))
+	return err
+}
+
+func (report *Report) String() string {
+	var buf bytes.Buffer
+	report.Write(&buf)
+
+	return buf.String()
+}
+
+func formatValue(v interface{}) string {
+	if v == nil {
+		return "(none)"
+	}
+
+	data, err := json.Marshal(v)
+	if err != nil {
+		return fmt.Sprint(v)
+	}
+
+	return string(data)
+}
+
+// index holds the resources of one side by kind and name
+type index struct {
+	environments map[int]string
+	deployGroups map[int]string
+	commands     map[int]string
+	projects     map[int]string
+	stages       map[int]string
+	resources    map[string]map[string]*resource
+}
+
+// resource is a model with the references to other resources it holds
+// resolved to their permalink or command text
+type resource struct {
+	model      interface{}
+	references map[string]interface{}
+}
+
+// fields holding ids of other resources, compared by what they resolve to
+var (
+	projectReferences = []string{"build_command_id", "environment_variable_group_ids"}
+	stageReferences   = []string{"project_id", "command_ids", "next_stage_ids", "template_stage_id", "deploy_group_ids"}
+)
+
+func newIndex(archive *backup.Archive) *index {
+	idx := &index{
+		environments: map[int]string{},
+		deployGroups: map[int]string{},
+		commands:     map[int]string{},
+		projects:     map[int]string{},
+		stages:       map[int]string{},
+		resources: map[string]map[string]*resource{
+			"environment": {},
+			"command":     {},
+			"project":     {},
+			"stage":       {},
+		},
+	}
+
+	for _, environment := range archive.Environments {
+		name := str(environment.Permalink)
+		if name == "" {
+			name = str(environment.Name)
+		}
+		idx.environments[id(environment.ID)] = name
+		idx.resources["environment"][name] = &resource{model: environment}
+	}
+	for _, deployGroup := range archive.DeployGroups {
+		idx.deployGroups[id(deployGroup.ID)] = str(deployGroup.Permalink)
+	}
+	for _, project := range archive.Projects {
+		idx.projects[id(project.ID)] = str(project.Permalink)
+	}
+	for _, stage := range archive.Stages {
+		idx.stages[id(stage.ID)] = str(stage.Permalink)
+	}
+	for _, command := range archive.Commands {
+		idx.commands[id(command.ID)] = str(command.Command)
+
+		name := strconv.Quote(str(command.Command))
+		if command.ProjectID != nil {
+			name = idx.project(*command.ProjectID) + "/" + name
+		}
+		idx.resources["command"][name] = &resource{}
+	}
+
+	for _, project := range archive.Projects {
+		// groups aren't archived, so they are compared by id
+		groups := []interface{}{}
+		for _, groupID := range sortedIDs(project.EnvironmentVariableGroupIds) {
+			groups = append(groups, groupID)
+		}
+		references := map[string]interface{}{"environment_variable_groups": groups}
+		if project.BuildCommandID != nil {
+			references["build_command"] = idx.command(*project.BuildCommandID)
+		}
+
+		// variables are matched by name and scope, scopes name what
+		// they point to
+		scoped := *project
+		scoped.EnvironmentVariableAttributes = nil
+		for _, variable := range project.EnvironmentVariableAttributes {
+			v := *variable
+			if v.ScopeTypeAndID != nil {
+				v.ScopeTypeAndID = samson.String(idx.scope(*v.ScopeTypeAndID))
+			}
+			scoped.EnvironmentVariableAttributes = append(scoped.EnvironmentVariableAttributes, &v)
+		}
+		idx.resources["project"][str(project.Permalink)] = &resource{model: &scoped, references: references}
+	}
+
+	for _, stage := range archive.Stages {
+		// lists are always set, samson returns empty ones which archives
+		// leave out
+		commands := []interface{}{}
+		for _, commandID := range stage.CommandIds {
+			commands = append(commands, idx.command(id(commandID)))
+		}
+		next := []interface{}{}
+		for _, stageID := range stage.NextStageIds {
+			next = append(next, idx.stage(id(stageID)))
+		}
+		deployGroups := []interface{}{}
+		for _, deployGroupID := range sortedIDs(stage.DeployGroupIds) {
+			deployGroups = append(deployGroups, idx.deployGroup(deployGroupID))
+		}
+		references := map[string]interface{}{
+			"commands":      commands,
+			"next_stages":   next,
+			"deploy_groups": deployGroups,
+		}
+		if stage.TemplateStageID != nil {
+			references["template_stage"] = idx.stage(*stage.TemplateStageID)
+		}
+
+		name := idx.project(id(stage.ProjectID)) + "/" + str(stage.Permalink)
+		idx.resources["stage"][name] = &resource{model: stage, references: references}
+	}
+
+	return idx
+}
+
+func (idx *index) project(projectID int) string {
+	if permalink, ok := idx.projects[projectID]; ok {
+		return permalink
+	}
+
+	return fmt.Sprintf("#%d", projectID)
+}
+
+func (idx *index) stage(stageID int) string {
+	if permalink, ok := idx.stages[stageID]; ok {
+		return permalink
+	}
+
+	return fmt.Sprintf("#%d", stageID)
+}
+
+func (idx *index) deployGroup(deployGroupID int) string {
+	if permalink, ok := idx.deployGroups[deployGroupID]; ok {
+		return permalink
+	}
+
+	return fmt.Sprintf("#%d", deployGroupID)
+}
+
+func (idx *index) command(commandID int) string {
+	if command, ok := idx.commands[commandID]; ok {
+		return command
+	}
+
+	return fmt.Sprintf("#%d", commandID)
+}
+
+// scope names an environment variable scope like Environment-1 or
+// DeployGroup-2 by the permalink of what it points to, scopes of snapshots
+// without deploy groups keep their id
+func (idx *index) scope(scope string) string {
+	parts := strings.SplitN(scope, "-", 2)
+	if len(parts) != 2 {
+		return scope
+	}
+
+	scopeID, err := strconv.Atoi(parts[1])
+	if err != nil {
+		return scope
+	}
+
+	var names map[int]string
+	switch parts[0] {
+	case "Environment":
+		names = idx.environments
+	case "DeployGroup":
+		names = idx.deployGroups
+	default:
+		return scope
+	}
+	if name, ok := names[scopeID]; ok {
+		return parts[0] + " " + name
+	}
+
+	return scope
+}
+
+// sortedIDs returns the ids of an unordered list
+func sortedIDs(ids []*int) []int {
+	sorted := make([]int, 0, len(ids))
+	for _, i := range ids {
+		if i != nil {
+			sorted = append(sorted, *i)
+		}
+	}
+	sort.Ints(sorted)
+
+	return sorted
+}
+
+func id(i *int) int {
+	if i == nil {
+		return 0
+	}
+
+	return *i
+}
+
+func str(s *string) string {
+	if s == nil {
+		return ""
+	}
+
+	return *s
+}
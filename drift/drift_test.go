@@ -0,0 +1,248 @@
+package drift
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	samson "github.com/tolgaakyuz/samson-go"
+	"github.com/tolgaakyuz/samson-go/backup"
+	"github.com/tolgaakyuz/samson-go/internal/samsontest"
+)
+
+func archive(t *testing.T, data string) *backup.Archive {
+	a, err := backup.Read(strings.NewReader(data))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return a
+}
+
+const source = `{"version": 1,
+	"environments": [{"id": 3, "name": "Production", "permalink": "production", "production": true}],
+	"commands": [
+		{"id": 20, "command": "./notify.sh"},
+		{"id": 21, "command": "docker build .", "project_id": 7},
+		{"id": 22, "command": "bundle exec cap deploy", "project_id": 7}
+	],
+	"projects": [{"id": 7, "name": "Example", "permalink": "example", "build_command_id": 21, "token": "a",
+		"environment_variables_attributes": [{"id": 4, "name": "RAILS_ENV", "value": "production", "scope_type_and_id": "Environment-3"},
+			{"id": 5, "name": "REGION", "value": "eu", "scope_type_and_id": "DeployGroup-1"}]}],
+	"stages": [
+		{"id": 30, "name": "Production", "permalink": "production", "project_id": 7, "command_ids": [22, 20],
+			"slack_webhooks_attributes": [{"id": 9, "webhook_url": "https://hooks.slack.com/x", "channel": "deploys", "after_deploy": true}]},
+		{"id": 31, "name": "Staging", "permalink": "staging", "project_id": 7, "command_ids": [22], "next_stage_ids": [30]}
+	],
+	"deploy_groups": [{"id": 1, "name": "EU", "permalink": "eu", "environment_id": 3}]}`
+
+// the same instance with different ids
+const copied = `{"version": 1,
+	"environments": [{"id": 103, "name": "Production", "permalink": "production", "production": true}],
+	"commands": [
+		{"id": 120, "command": "./notify.sh"},
+		{"id": 121, "command": "docker build .", "project_id": 107},
+		{"id": 122, "command": "bundle exec cap deploy", "project_id": 107}
+	],
+	"projects": [{"id": 107, "name": "Example", "permalink": "example", "build_command_id": 121, "token": "b",
+		"environment_variables_attributes": [{"id": 1004, "name": "RAILS_ENV", "value": "production", "scope_type_and_id": "Environment-103"},
+			{"id": 1005, "name": "REGION", "value": "eu", "scope_type_and_id": "DeployGroup-101"}]}],
+	"stages": [
+		{"id": 130, "name": "Production", "permalink": "production", "project_id": 107, "command_ids": [122, 120],
+			"slack_webhooks_attributes": [{"id": 1009, "webhook_url": "https://hooks.slack.com/x", "channel": "deploys", "after_deploy": true}]},
+		{"id": 131, "name": "Staging", "permalink": "staging", "project_id": 107, "command_ids": [122], "next_stage_ids": [130]}
+	],
+	"deploy_groups": [{"id": 101, "name": "EU", "permalink": "eu", "environment_id": 103}]}`
+
+func TestCompareMatchesAcrossIds(t *testing.T) {
+	assert := assert.New(t)
+
+	report := Compare(archive(t, source), archive(t, copied))
+	assert.True(report.Empty())
+	assert.Equal("No differences.\n", report.String())
+}
+
+func TestCompare(t *testing.T) {
+	assert := assert.New(t)
+
+	changed := strings.NewReplacer(
+		`"name": "Example"`, `"name": "Renamed"`,
+		`"value": "production"`, `"value": "staging"`,
+		`"command_ids": [122, 120]`, `"command_ids": [120, 122]`,
+		`"channel": "deploys"`, `"channel": "releases"`,
+		`{"id": 120, "command": "./notify.sh"}`, `{"id": 120, "command": "./notify.sh --all"}`,
+		`"production": true`, `"production": false`,
+	).Replace(copied)
+
+	report := Compare(archive(t, source), archive(t, changed))
+	assert.False(report.Empty())
+
+	var summary []string
+	for _, difference := range report.Differences {
+		summary = append(summary, string(difference.Kind)+" "+difference.Resource+" "+difference.Name)
+	}
+	assert.Equal([]string{
+		`changed environment production`,
+		`added command "./notify.sh --all"`,
+		`removed command "./notify.sh"`,
+		`changed project example`,
+		`changed stage example/production`,
+	}, summary)
+
+	assert.Equal([]samson.FieldChange{
+		{Field: "production", Before: true, After: false},
+	}, report.Differences[0].Fields)
+	assert.Equal([]samson.FieldChange{
+		{Field: "environment_variables_attributes[RAILS_ENV (Environment production)].value", Before: "production", After: "staging"},
+		{Field: "name", Before: "Example", After: "Renamed"},
+	}, report.Differences[3].Fields)
+
+	fields := report.Differences[4].Fields
+	assert.Len(fields, 3)
+	assert.Equal("commands", fields[0].Field)
+	assert.Equal([]interface{}{"bundle exec cap deploy", "./notify.sh"}, fields[0].Before)
+	assert.Equal([]interface{}{"./notify.sh --all", "bundle exec cap deploy"}, fields[0].After)
+	assert.Equal("slack_webhooks_attributes[https://hooks.slack.com/x #deploys]", fields[1].Field)
+	assert.Nil(fields[2].Before)
+	assert.Equal(map[string]interface{}{"after_deploy": true, "channel": "releases", "webhook_url": "https://hooks.slack.com/x"}, fields[2].After)
+
+	out := report.String()
+	assert.Contains(out, "~ environment production changed\n    production: true -> false\n")
+	assert.Contains(out, "- command \"./notify.sh\" removed\n")
+	assert.Contains(out, "+ command \"./notify.sh --all\" added\n")
+	assert.Contains(out, "    slack_webhooks_attributes[https://hooks.slack.com/x #releases]: (none) -> {\"after_deploy\":true,\"channel\":\"releases\",\"webhook_url\":\"https://hooks.slack.com/x\"}\n")
+	assert.Contains(out, "\n5 differences.\n")
+}
+
+func TestCompareStageReferences(t *testing.T) {
+	assert := assert.New(t)
+
+	changed := strings.NewReplacer(
+		`"next_stage_ids": [130]`, `"next_stage_ids": [131]`,
+		`"permalink": "example"`, `"permalink": "other"`,
+	).Replace(copied)
+
+	report := Compare(archive(t, source), archive(t, changed))
+
+	var names []string
+	for _, difference := range report.Differences {
+		names = append(names, string(difference.Kind)+" "+difference.Resource+" "+difference.Name)
+	}
+	assert.Equal([]string{
+		`removed command example/"bundle exec cap deploy"`,
+		`removed command example/"docker build ."`,
+		`added command other/"bundle exec cap deploy"`,
+		`added command other/"docker build ."`,
+		`removed project example`,
+		`added project other`,
+		`removed stage example/production`,
+		`removed stage example/staging`,
+		`added stage other/production`,
+		`added stage other/staging`,
+	}, names)
+
+	report = Compare(archive(t, source), archive(t, strings.Replace(copied, `"next_stage_ids": [130]`, `"next_stage_ids": [131]`, 1)))
+	assert.Len(report.Differences, 1)
+	assert.Equal([]samson.FieldChange{
+		{Field: "next_stages", Before: []interface{}{"production"}, After: []interface{}{"staging"}},
+	}, report.Differences[0].Fields)
+}
+
+func TestCompareDeployGroupScopes(t *testing.T) {
+	assert := assert.New(t)
+
+	changed := strings.Replace(copied, `"permalink": "eu"`, `"permalink": "eu-west"`, 1)
+	report := Compare(archive(t, source), archive(t, changed))
+	assert.Len(report.Differences, 1)
+	fields := report.Differences[0].Fields
+	assert.Len(fields, 2)
+	assert.Equal("environment_variables_attributes[REGION (DeployGroup eu)]", fields[0].Field)
+	assert.Nil(fields[0].After)
+	assert.Equal("environment_variables_attributes[REGION (DeployGroup eu-west)]", fields[1].Field)
+	assert.Nil(fields[1].Before)
+
+	// snapshots written before deploy groups were archived keep the ids
+	old := strings.Replace(source, `"deploy_groups": [{"id": 1, "name": "EU", "permalink": "eu", "environment_id": 3}]`, `"deploy_groups": []`, 1)
+	report = Compare(archive(t, old), archive(t, copied))
+	assert.Len(report.Differences, 1)
+	fields = report.Differences[0].Fields
+	assert.Len(fields, 2)
+	assert.Equal("environment_variables_attributes[REGION (DeployGroup eu)]", fields[0].Field)
+	assert.Equal("environment_variables_attributes[REGION (DeployGroup-1)]", fields[1].Field)
+}
+
+func TestCompareGroups(t *testing.T) {
+	assert := assert.New(t)
+
+	withGroups := func(data, stage, project string) string {
+		return strings.NewReplacer(
+			`"command_ids": [22], "next_stage_ids": [30]}`, `"command_ids": [22], "next_stage_ids": [30], "deploy_group_ids": `+stage+`}`,
+			`"command_ids": [122], "next_stage_ids": [130]}`, `"command_ids": [122], "next_stage_ids": [130], "deploy_group_ids": `+stage+`}`,
+			`"build_command_id": 21,`, `"build_command_id": 21, "environment_variable_group_ids": `+project+`,`,
+			`"build_command_id": 121,`, `"build_command_id": 121, "environment_variable_group_ids": `+project+`,`,
+		).Replace(data)
+	}
+
+	// deploy groups are compared by permalink
+	report := Compare(archive(t, withGroups(source, "[1]", "[1]")), archive(t, withGroups(copied, "[101]", "[1]")))
+	assert.True(report.Empty())
+
+	report = Compare(archive(t, withGroups(source, "[1]", "[1]")), archive(t, withGroups(copied, "[102, 101]", "null")))
+	assert.Len(report.Differences, 2)
+	assert.Equal("project example", report.Differences[0].Resource+" "+report.Differences[0].Name)
+	assert.Equal([]samson.FieldChange{
+		{Field: "environment_variable_groups", Before: []interface{}{1}, After: []interface{}{}},
+	}, report.Differences[0].Fields)
+	assert.Equal("stage example/staging", report.Differences[1].Resource+" "+report.Differences[1].Name)
+	assert.Equal([]samson.FieldChange{
+		{Field: "deploy_groups", Before: []interface{}{"eu"}, After: []interface{}{"eu", "#102"}},
+	}, report.Differences[1].Fields)
+}
+
+func TestLiveAndSnapshot(t *testing.T) {
+	assert := assert.New(t)
+
+	fake := samsontest.New()
+	fake.Add("environments", `{"id": 3, "name": "Production", "permalink": "production", "production": "1"}`)
+	fake.Add("projects", `{"id": 7, "name": "Example", "permalink": "example", "created_at": "2018-03-26T11:52:01Z",
+		"environment_variable_group_ids": []}`)
+	// samson returns empty lists, which the snapshot leaves out
+	fake.Add("stages", `{"id": 30, "name": "Staging", "permalink": "staging", "project_id": 7,
+		"command_ids": [], "next_stage_ids": [], "deploy_group_ids": []}`)
+	server := fake.Start()
+	defer server.Close()
+
+	client := samson.New("token")
+	client.BaseURL = server.URL
+
+	live, err := Live(client)
+	assert.Nil(err)
+
+	dir, err := ioutil.TempDir("", "drift")
+	assert.Nil(err)
+	defer os.RemoveAll(dir)
+
+	path := filepath.Join(dir, "snapshot.json")
+	file, err := os.Create(path)
+	assert.Nil(err)
+	assert.Nil(live.Write(file))
+	file.Close()
+
+	snapshot, err := Snapshot(path)
+	assert.Nil(err)
+	assert.True(Compare(snapshot, live).Empty())
+
+	fake.Add("projects", `{"id": 8, "name": "Other", "permalink": "other", "created_at": "2018-03-26T11:52:01Z"}`)
+	live, err = Live(client)
+	assert.Nil(err)
+
+	report := Compare(snapshot, live)
+	assert.Len(report.Differences, 1)
+	assert.Equal(Difference{Kind: Added, Resource: "project", Name: "other"}, report.Differences[0])
+
+	_, err = Snapshot(filepath.Join(dir, "missing.json"))
+	assert.NotNil(err)
+}
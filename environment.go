@@ -13,7 +13,24 @@ type EnvironmentService service
 type Environment struct {
 	ID         *int    `json:"id,omitempty"`
 	Name       *string `json:"name,omitempty"`
-	Production *string `json:"production,omitempty"`
+	Permalink  *string `json:"permalink,omitempty"`
+	Production *bool   `json:"production,omitempty"`
+}
+
+// UnmarshalJSON decodes an environment, accepting "1" and "0" as production flag
+func (e *Environment) UnmarshalJSON(data []byte) error {
+	type environment Environment
+	aux := struct {
+		*environment
+		Production json.RawMessage `json:"production,omitempty"`
+	}{environment: (*environment)(e)}
+
+	err := json.Unmarshal(data, &aux)
+	if err != nil {
+		return err
+	}
+
+	return decodeFlexBool(aux.Production, &e.Production)
 }
 
 // List returns all environments
@@ -97,3 +114,25 @@ func (service *EnvironmentService) Delete(id int) (*Call, error) {
 
 	return call, call.Do(nil)
 }
+
+// DeployGroups returns the deploy groups of an environment
+func (service *EnvironmentService) DeployGroups(id int) ([]*DeployGroup, *Call, error) {
+	path := fmt.Sprintf("/environments/%d/deploy_groups.json", id)
+	method := "GET"
+
+	call, err := service.s.NewCall(method, path, nil, nil, nil)
+	if err != nil {
+		return nil, call, err
+	}
+
+	type response struct {
+		DeployGroups []*DeployGroup `json:"deploy_groups,omitempty"`
+	}
+	var res response
+	err = call.Do(&res)
+	if err != nil {
+		return nil, call, err
+	}
+
+	return res.DeployGroups, call, nil
+}
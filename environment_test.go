@@ -45,7 +45,7 @@ func ExampleEnvironmentService_Upsert_create() {
 
 	environment := &Environment{
 		Name:       String("staging"),
-		Production: String("1"),
+		Production: Bool(true),
 	}
 
 	environment, _, err := client.Environments.Upsert(environment)
@@ -61,7 +61,7 @@ func ExampleEnvironmentService_Upsert_update() {
 
 	environment := &Environment{
 		Name:       String("staging"),
-		Production: String("1"),
+		Production: Bool(true),
 	}
 
 	environment, _, err := client.Environments.Upsert(environment)
@@ -163,7 +163,8 @@ func TestEnvironmentServiceGet(t *testing.T) {
 	assert.Nil(err)
 	assert.Equal(*environment.ID, 1)
 	assert.Equal(*environment.Name, "production")
-	assert.Equal(*environment.Production, "1")
+	assert.Equal(*environment.Permalink, "production")
+	assert.Equal(*environment.Production, true)
 	assert.IsType(&Call{}, call)
 }
 
@@ -215,7 +216,7 @@ func TestEnvironmentServiceCreate(t *testing.T) {
 		err := json.NewDecoder(r.Body).Decode(&payload)
 		assert.Nil(err)
 		assert.Equal(payload["name"], "staging")
-		assert.Equal(payload["production"], "1")
+		assert.Equal(payload["production"], true)
 
 		w.WriteHeader(200)
 		fmt.Fprintln(w, readTestData("environment_staging.json"))
@@ -229,7 +230,7 @@ func TestEnvironmentServiceCreate(t *testing.T) {
 
 	environment := &Environment{
 		Name:       String("staging"),
-		Production: String("1"),
+		Production: Bool(true),
 	}
 
 	environment, call, err := client.Environments.Upsert(environment)
@@ -270,7 +271,7 @@ func TestEnvironmentServiceUpdate(t *testing.T) {
 		err := json.NewDecoder(r.Body).Decode(&payload)
 		assert.Nil(err)
 		assert.Equal(payload["name"], "preview")
-		assert.Equal(payload["production"], "0")
+		assert.Equal(payload["production"], false)
 
 		w.WriteHeader(200)
 		fmt.Fprintln(w, readTestData("environment_staging.json"))
@@ -281,7 +282,7 @@ func TestEnvironmentServiceUpdate(t *testing.T) {
 	defer server.Close()
 
 	environment.Name = String("preview")
-	environment.Production = String("0")
+	environment.Production = Bool(false)
 
 	environment, call, err := client.Environments.Upsert(environment)
 	assert.Nil(err)
@@ -297,7 +298,7 @@ func TestEnvironmentServiceUpsert_fail_1(t *testing.T) {
 
 	environment := &Environment{
 		Name:       String("staging"),
-		Production: String("1"),
+		Production: Bool(true),
 	}
 
 	environment, call, err := client.Environments.Upsert(environment)
@@ -323,7 +324,7 @@ func TestEnvironmentServiceUpsert_fail_2(t *testing.T) {
 
 	environment := &Environment{
 		Name:       String("staging"),
-		Production: String("1"),
+		Production: Bool(true),
 	}
 
 	environment, call, err := client.Environments.Upsert(environment)
@@ -368,3 +369,41 @@ func TestEnvironmentServiceDelete_fail(t *testing.T) {
 	assert.NotNil(err)
 	assert.IsType(&Call{}, call)
 }
+
+func TestEnvironmentServiceDeployGroups(t *testing.T) {
+	var err error
+	assert := assert.New(t)
+
+	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal("GET", r.Method)
+		assert.Equal("/environments/1/deploy_groups.json", r.URL.Path)
+		checkHeaders(r, assert)
+
+		w.WriteHeader(200)
+		fmt.Fprintln(w, readTestData("deploy_groups.json"))
+	})
+
+	server := httptest.NewServer(handler)
+	defer server.Close()
+
+	client = New(token)
+	client.BaseURL = server.URL
+
+	deployGroups, call, err := client.Environments.DeployGroups(1)
+	assert.Nil(err)
+	assert.IsType(&Call{}, call)
+	assert.Equal(2, len(deployGroups))
+}
+
+func TestEnvironmentServiceDeployGroups_fail(t *testing.T) {
+	var err error
+	assert := assert.New(t)
+
+	client = New(token)
+	client.BaseURL = "^http://localhost"
+
+	deployGroups, call, err := client.Environments.DeployGroups(1)
+	assert.NotNil(err)
+	assert.IsType(&Call{}, call)
+	assert.Nil(deployGroups)
+}
@@ -0,0 +1,154 @@
+package samson
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"time"
+)
+
+// EnvironmentVariableGroupService service
+type EnvironmentVariableGroupService service
+
+// EnvironmentVariableGroup model for variables shared by projects
+type EnvironmentVariableGroup struct {
+	ID                            *int                   `json:"id,omitempty"`
+	Name                          *string                `json:"name,omitempty"`
+	Comment                       *string                `json:"comment,omitempty"`
+	EnvironmentVariableAttributes []*EnvironmentVariable `json:"environment_variables_attributes,omitempty"`
+	CreatedAt                     *time.Time             `json:"created_at,omitempty"`
+	UpdatedAt                     *time.Time             `json:"updated_at,omitempty"`
+}
+
+// List returns all environment variable groups
+func (service *EnvironmentVariableGroupService) List() ([]*EnvironmentVariableGroup, *Call, error) {
+	path := "/environment_variable_groups.json"
+	method := "GET"
+
+	call, err := service.s.NewCall(method, path, nil, nil, nil)
+	if err != nil {
+		return nil, call, err
+	}
+
+	type response struct {
+		EnvironmentVariableGroups []*EnvironmentVariableGroup `json:"environment_variable_groups,omitempty"`
+	}
+	var res response
+	err = call.Do(&res)
+	if err != nil {
+		return nil, call, err
+	}
+
+	return res.EnvironmentVariableGroups, call, nil
+}
+
+// Get returns a single environment variable group resource
+func (service *EnvironmentVariableGroupService) Get(id int) (*EnvironmentVariableGroup, *Call, error) {
+	path := fmt.Sprintf("/environment_variable_groups/%d.json", id)
+	method := "GET"
+
+	call, err := service.s.NewCall(method, path, nil, nil, nil)
+	if err != nil {
+		return nil, call, err
+	}
+
+	var group EnvironmentVariableGroup
+	err = call.Do(&group)
+	if err != nil {
+		return nil, call, err
+	}
+
+	return &group, call, nil
+}
+
+// Upsert updates or creates a new environment variable group resource
+// Variables are removed from the group by setting their Destroy flag
+func (service *EnvironmentVariableGroupService) Upsert(group *EnvironmentVariableGroup) (*EnvironmentVariableGroup, *Call, error) {
+	bytesArray, _ := json.Marshal(group)
+
+	var path string
+	var method string
+
+	if group.ID != nil {
+		path = fmt.Sprintf("/environment_variable_groups/%d.json", *group.ID)
+		method = "PUT"
+	} else {
+		path = "/environment_variable_groups.json"
+		method = "POST"
+	}
+
+	call, err := service.s.NewCall(method, path, nil, nil, bytes.NewReader(bytesArray))
+	if err != nil {
+		return nil, call, err
+	}
+
+	err = call.Do(&group)
+	if err != nil {
+		return nil, call, err
+	}
+
+	return group, call, nil
+}
+
+// Delete deletes a sinlge environment variable group resource
+func (service *EnvironmentVariableGroupService) Delete(id int) (*Call, error) {
+	path := fmt.Sprintf("/environment_variable_groups/%d.json", id)
+	method := "DELETE"
+
+	call, err := service.s.NewCall(method, path, nil, nil, nil)
+	if err != nil {
+		return call, err
+	}
+
+	return call, call.Do(nil)
+}
+
+// Attach includes an environment variable group in a project
+func (service *EnvironmentVariableGroupService) Attach(id int, projectID int) (*Project, *Call, error) {
+	project, call, err := service.s.Projects.Get(projectID)
+	if err != nil {
+		return nil, call, err
+	}
+
+	groupIDs := []int{}
+	for _, groupID := range project.EnvironmentVariableGroupIds {
+		if groupID == nil {
+			continue
+		}
+
+		if *groupID == id {
+			return project, call, nil
+		}
+		groupIDs = append(groupIDs, *groupID)
+	}
+
+	return service.s.Projects.SetEnvironmentVariableGroups(projectID, append(groupIDs, id))
+}
+
+// Detach removes an environment variable group from a project
+func (service *EnvironmentVariableGroupService) Detach(id int, projectID int) (*Project, *Call, error) {
+	project, call, err := service.s.Projects.Get(projectID)
+	if err != nil {
+		return nil, call, err
+	}
+
+	found := false
+	groupIDs := []int{}
+	for _, groupID := range project.EnvironmentVariableGroupIds {
+		if groupID == nil {
+			continue
+		}
+
+		if *groupID == id {
+			found = true
+			continue
+		}
+		groupIDs = append(groupIDs, *groupID)
+	}
+
+	if !found {
+		return project, call, nil
+	}
+
+	return service.s.Projects.SetEnvironmentVariableGroups(projectID, groupIDs)
+}
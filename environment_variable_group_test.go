@@ -0,0 +1,340 @@
+package samson
+
+import (
+	"encoding/json"
+	"fmt"
+	"log"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func ExampleEnvironmentVariableGroupService_Upsert() {
+	client := New("token")
+
+	group := &EnvironmentVariableGroup{
+		Name: String("logging"),
+		EnvironmentVariableAttributes: []*EnvironmentVariable{
+			{
+				Name:  String("LOG_ENDPOINT"),
+				Value: String("https://logs.example.com"),
+			},
+		},
+	}
+
+	group, _, err := client.EnvironmentVariableGroups.Upsert(group)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	_, _, err = client.EnvironmentVariableGroups.Attach(*group.ID, 2)
+	if err != nil {
+		log.Fatal(err)
+	}
+}
+
+func TestEnvironmentVariableGroupServiceList(t *testing.T) {
+	var err error
+	assert := assert.New(t)
+
+	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal("GET", r.Method)
+		assert.Equal("/environment_variable_groups.json", r.URL.Path)
+		checkHeaders(r, assert)
+
+		w.WriteHeader(200)
+		fmt.Fprintln(w, readTestData("environment_variable_groups.json"))
+	})
+
+	server := httptest.NewServer(handler)
+	defer server.Close()
+
+	client = New(token)
+	client.BaseURL = server.URL
+
+	groups, call, err := client.EnvironmentVariableGroups.List()
+	assert.Nil(err)
+	assert.IsType(&Call{}, call)
+	assert.Equal(2, len(groups))
+	assert.Equal(2, len(groups[0].EnvironmentVariableAttributes))
+}
+
+func TestEnvironmentVariableGroupServiceList_fail(t *testing.T) {
+	var err error
+	assert := assert.New(t)
+
+	client = New(token)
+	client.BaseURL = "^http://localhost"
+
+	groups, call, err := client.EnvironmentVariableGroups.List()
+	assert.NotNil(err)
+	assert.IsType(&Call{}, call)
+	assert.Nil(groups)
+}
+
+func TestEnvironmentVariableGroupServiceGet(t *testing.T) {
+	var err error
+	assert := assert.New(t)
+
+	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal("GET", r.Method)
+		assert.Equal("/environment_variable_groups/1.json", r.URL.Path)
+		checkHeaders(r, assert)
+
+		w.WriteHeader(200)
+		fmt.Fprintln(w, readTestData("environment_variable_group.json"))
+	})
+
+	server := httptest.NewServer(handler)
+	defer server.Close()
+
+	client = New(token)
+	client.BaseURL = server.URL
+
+	group, call, err := client.EnvironmentVariableGroups.Get(1)
+	assert.Nil(err)
+	assert.IsType(&Call{}, call)
+	assert.Equal("logging", *group.Name)
+	assert.Equal("Environment-1", *group.EnvironmentVariableAttributes[1].ScopeTypeAndID)
+}
+
+func TestEnvironmentVariableGroupServiceGet_fail(t *testing.T) {
+	var err error
+	assert := assert.New(t)
+
+	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(200)
+		fmt.Fprintln(w, "malformed json response")
+	})
+
+	server := httptest.NewServer(handler)
+	defer server.Close()
+
+	client = New(token)
+	client.BaseURL = server.URL
+
+	group, call, err := client.EnvironmentVariableGroups.Get(1)
+	assert.NotNil(err)
+	assert.IsType(&Call{}, call)
+	assert.Nil(group)
+}
+
+func TestEnvironmentVariableGroupServiceCreate(t *testing.T) {
+	var err error
+	assert := assert.New(t)
+
+	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal("POST", r.Method)
+		assert.Equal("/environment_variable_groups.json", r.URL.Path)
+		checkHeaders(r, assert)
+
+		var payload map[string]interface{}
+		err := json.NewDecoder(r.Body).Decode(&payload)
+		assert.Nil(err)
+		assert.Equal("logging", payload["name"])
+		variables := payload["environment_variables_attributes"].([]interface{})
+		assert.Equal("LOG_ENDPOINT", variables[0].(map[string]interface{})["name"])
+
+		w.WriteHeader(200)
+		fmt.Fprintln(w, readTestData("environment_variable_group.json"))
+	})
+
+	server := httptest.NewServer(handler)
+	defer server.Close()
+
+	client = New(token)
+	client.BaseURL = server.URL
+
+	group := &EnvironmentVariableGroup{
+		Name: String("logging"),
+		EnvironmentVariableAttributes: []*EnvironmentVariable{
+			{
+				Name:  String("LOG_ENDPOINT"),
+				Value: String("https://logs.example.com"),
+			},
+		},
+	}
+
+	group, call, err := client.EnvironmentVariableGroups.Upsert(group)
+	assert.Nil(err)
+	assert.IsType(&Call{}, call)
+	assert.Equal(1, *group.ID)
+}
+
+func TestEnvironmentVariableGroupServiceUpdate(t *testing.T) {
+	var err error
+	assert := assert.New(t)
+
+	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal("PUT", r.Method)
+		assert.Equal("/environment_variable_groups/1.json", r.URL.Path)
+		checkHeaders(r, assert)
+
+		var payload map[string]interface{}
+		err := json.NewDecoder(r.Body).Decode(&payload)
+		assert.Nil(err)
+		variables := payload["environment_variables_attributes"].([]interface{})
+		assert.Equal(float64(2), variables[0].(map[string]interface{})["id"])
+		assert.Equal(true, variables[0].(map[string]interface{})["_destroy"])
+
+		w.WriteHeader(200)
+		fmt.Fprintln(w, readTestData("environment_variable_group.json"))
+	})
+
+	server := httptest.NewServer(handler)
+	defer server.Close()
+
+	client = New(token)
+	client.BaseURL = server.URL
+
+	group := &EnvironmentVariableGroup{
+		ID: Int(1),
+		EnvironmentVariableAttributes: []*EnvironmentVariable{
+			{
+				ID:      Int(2),
+				Destroy: Bool(true),
+			},
+		},
+	}
+
+	group, call, err := client.EnvironmentVariableGroups.Upsert(group)
+	assert.Nil(err)
+	assert.IsType(&Call{}, call)
+	assert.NotNil(group.UpdatedAt)
+}
+
+func TestEnvironmentVariableGroupServiceUpsert_fail(t *testing.T) {
+	var err error
+	assert := assert.New(t)
+
+	client = New(token)
+	client.BaseURL = "^http://localhost"
+
+	group, call, err := client.EnvironmentVariableGroups.Upsert(&EnvironmentVariableGroup{})
+	assert.NotNil(err)
+	assert.IsType(&Call{}, call)
+	assert.Nil(group)
+}
+
+func TestEnvironmentVariableGroupServiceDelete(t *testing.T) {
+	var err error
+	assert := assert.New(t)
+
+	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal("DELETE", r.Method)
+		assert.Equal("/environment_variable_groups/1.json", r.URL.Path)
+		checkHeaders(r, assert)
+
+		w.WriteHeader(200)
+		fmt.Fprintln(w, "")
+	})
+
+	server := httptest.NewServer(handler)
+	defer server.Close()
+
+	client = New(token)
+	client.BaseURL = server.URL
+
+	call, err := client.EnvironmentVariableGroups.Delete(1)
+	assert.Nil(err)
+	assert.IsType(&Call{}, call)
+}
+
+func environmentVariableGroupProjectHandler(assert *assert.Assertions, expected []interface{}) http.HandlerFunc {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal("/projects/2.json", r.URL.Path)
+		checkHeaders(r, assert)
+
+		if r.Method == "PUT" {
+			var payload map[string]interface{}
+			err := json.NewDecoder(r.Body).Decode(&payload)
+			assert.Nil(err)
+			assert.Equal(expected, payload["environment_variable_group_ids"])
+		}
+
+		w.WriteHeader(200)
+		fmt.Fprintln(w, readTestData("project.json"))
+	})
+}
+
+func TestEnvironmentVariableGroupServiceAttach(t *testing.T) {
+	var err error
+	assert := assert.New(t)
+
+	server := httptest.NewServer(environmentVariableGroupProjectHandler(assert, []interface{}{float64(1), float64(2)}))
+	defer server.Close()
+
+	client = New(token)
+	client.BaseURL = server.URL
+
+	project, call, err := client.EnvironmentVariableGroups.Attach(2, 2)
+	assert.Nil(err)
+	assert.IsType(&Call{}, call)
+	assert.Equal(2, *project.ID)
+}
+
+func TestEnvironmentVariableGroupServiceAttach_attached(t *testing.T) {
+	var err error
+	assert := assert.New(t)
+
+	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal("GET", r.Method)
+
+		w.WriteHeader(200)
+		fmt.Fprintln(w, readTestData("project.json"))
+	})
+
+	server := httptest.NewServer(handler)
+	defer server.Close()
+
+	client = New(token)
+	client.BaseURL = server.URL
+
+	project, _, err := client.EnvironmentVariableGroups.Attach(1, 2)
+	assert.Nil(err)
+	assert.Equal(1, len(project.EnvironmentVariableGroupIds))
+}
+
+func TestEnvironmentVariableGroupServiceAttach_fail(t *testing.T) {
+	var err error
+	assert := assert.New(t)
+
+	client = New(token)
+	client.BaseURL = "^http://localhost"
+
+	project, call, err := client.EnvironmentVariableGroups.Attach(1, 2)
+	assert.NotNil(err)
+	assert.IsType(&Call{}, call)
+	assert.Nil(project)
+}
+
+func TestEnvironmentVariableGroupServiceDetach(t *testing.T) {
+	var err error
+	assert := assert.New(t)
+
+	server := httptest.NewServer(environmentVariableGroupProjectHandler(assert, []interface{}{}))
+	defer server.Close()
+
+	client = New(token)
+	client.BaseURL = server.URL
+
+	project, call, err := client.EnvironmentVariableGroups.Detach(1, 2)
+	assert.Nil(err)
+	assert.IsType(&Call{}, call)
+	assert.Equal(2, *project.ID)
+}
+
+func TestEnvironmentVariableGroupServiceDetach_fail(t *testing.T) {
+	var err error
+	assert := assert.New(t)
+
+	client = New(token)
+	client.BaseURL = "^http://localhost"
+
+	project, call, err := client.EnvironmentVariableGroups.Detach(1, 2)
+	assert.NotNil(err)
+	assert.IsType(&Call{}, call)
+	assert.Nil(project)
+}
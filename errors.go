@@ -1,7 +1,8 @@
 package samson
 
 type ErrorResponse struct {
-	Message string
+	Message    string `json:"message"`
+	StatusCode int    `json:"-"`
 }
 
 func (er ErrorResponse) Error() string {
@@ -0,0 +1,143 @@
+package samson
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+var jsonNull = []byte("null")
+
+// FlexInt is an int which decodes from numbers and numeric strings,
+// as Samson returns some ids as strings
+type FlexInt int
+
+// UnmarshalJSON decodes a number or a numeric string
+func (i *FlexInt) UnmarshalJSON(data []byte) error {
+	if bytes.Equal(data, jsonNull) {
+		return nil
+	}
+
+	s := string(data)
+	if len(data) > 0 && data[0] == '"' {
+		if err := json.Unmarshal(data, &s); err != nil {
+			return err
+		}
+	}
+
+	value, err := strconv.Atoi(strings.TrimSpace(s))
+	if err != nil {
+		return fmt.Errorf("invalid integer %s", data)
+	}
+
+	*i = FlexInt(value)
+	return nil
+}
+
+// Int returns the value as an int pointer, nil stays nil
+func (i *FlexInt) Int() *int {
+	if i == nil {
+		return nil
+	}
+
+	return Int(int(*i))
+}
+
+// FlexBool is a bool which decodes from booleans, 1 and 0, and their
+// string forms, as Samson returns some flags as "1" and "0"
+type FlexBool bool
+
+// UnmarshalJSON decodes a boolean, a number or a string
+func (b *FlexBool) UnmarshalJSON(data []byte) error {
+	if bytes.Equal(data, jsonNull) {
+		return nil
+	}
+
+	s := string(data)
+	if len(data) > 0 && data[0] == '"' {
+		if err := json.Unmarshal(data, &s); err != nil {
+			return err
+		}
+	}
+
+	switch strings.ToLower(strings.TrimSpace(s)) {
+	case "true", "1", "t":
+		*b = true
+	case "false", "0", "f", "":
+		*b = false
+	default:
+		return fmt.Errorf("invalid boolean %s", data)
+	}
+
+	return nil
+}
+
+// Bool returns the value as a bool pointer, nil stays nil
+func (b *FlexBool) Bool() *bool {
+	if b == nil {
+		return nil
+	}
+
+	return Bool(bool(*b))
+}
+
+// flexInts converts decoded FlexInt slices to int slices
+func flexInts(values []*FlexInt) []*int {
+	if values == nil {
+		return nil
+	}
+
+	ints := make([]*int, len(values))
+	for i, value := range values {
+		ints[i] = value.Int()
+	}
+
+	return ints
+}
+
+// The decode helpers set a model field from its raw json, like
+// encoding/json a missing key leaves the field unchanged and null clears it
+
+func decodeFlexInt(raw json.RawMessage, field **int) error {
+	if len(raw) == 0 {
+		return nil
+	}
+
+	var value *FlexInt
+	if err := json.Unmarshal(raw, &value); err != nil {
+		return err
+	}
+
+	*field = value.Int()
+	return nil
+}
+
+func decodeFlexInts(raw json.RawMessage, field *[]*int) error {
+	if len(raw) == 0 {
+		return nil
+	}
+
+	var values []*FlexInt
+	if err := json.Unmarshal(raw, &values); err != nil {
+		return err
+	}
+
+	*field = flexInts(values)
+	return nil
+}
+
+func decodeFlexBool(raw json.RawMessage, field **bool) error {
+	if len(raw) == 0 {
+		return nil
+	}
+
+	var value *FlexBool
+	if err := json.Unmarshal(raw, &value); err != nil {
+		return err
+	}
+
+	*field = value.Bool()
+	return nil
+}
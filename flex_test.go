@@ -0,0 +1,112 @@
+package samson
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFlexIntUnmarshalJSON(t *testing.T) {
+	assert := assert.New(t)
+
+	var values []*FlexInt
+	err := json.Unmarshal([]byte(`[1, "2", " 3 ", null]`), &values)
+	assert.Nil(err)
+	assert.Len(values, 4)
+	assert.Equal(1, *values[0].Int())
+	assert.Equal(2, *values[1].Int())
+	assert.Equal(3, *values[2].Int())
+	assert.Nil(values[3].Int())
+
+	var value FlexInt
+	assert.NotNil(json.Unmarshal([]byte(`"one"`), &value))
+	assert.NotNil(json.Unmarshal([]byte(`1.5`), &value))
+	assert.NotNil(json.Unmarshal([]byte(`true`), &value))
+}
+
+func TestFlexBoolUnmarshalJSON(t *testing.T) {
+	assert := assert.New(t)
+
+	var values []*FlexBool
+	err := json.Unmarshal([]byte(`[true, false, 1, 0, "1", "0", "true", "false", "", null]`), &values)
+	assert.Nil(err)
+	assert.Len(values, 10)
+	expected := []bool{true, false, true, false, true, false, true, false, false}
+	for i, e := range expected {
+		assert.Equal(e, *values[i].Bool())
+	}
+	assert.Nil(values[9].Bool())
+
+	var value FlexBool
+	assert.NotNil(json.Unmarshal([]byte(`"yes"`), &value))
+	assert.NotNil(json.Unmarshal([]byte(`2`), &value))
+}
+
+func TestFlexModels(t *testing.T) {
+	assert := assert.New(t)
+
+	command := &Command{}
+	assert.Nil(json.Unmarshal([]byte(`{"id": 1, "project_id": "2"}`), command))
+	assert.Equal(2, *command.ProjectID)
+	assert.Nil(json.Unmarshal([]byte(`{"id": 1, "project_id": 3}`), command))
+	assert.Equal(3, *command.ProjectID)
+
+	environment := &Environment{}
+	assert.Nil(json.Unmarshal([]byte(`{"id": 1, "production": "1"}`), environment))
+	assert.True(*environment.Production)
+	assert.Nil(json.Unmarshal([]byte(`{"id": 1, "production": false}`), environment))
+	assert.False(*environment.Production)
+
+	stage := &Stage{}
+	assert.Nil(json.Unmarshal([]byte(`{"id": 1, "command_ids": ["2", 3]}`), stage))
+	assert.Len(stage.CommandIds, 2)
+	assert.Equal(2, *stage.CommandIds[0])
+	assert.Equal(3, *stage.CommandIds[1])
+	assert.Equal(1, *stage.ID)
+
+	assert.NotNil(json.Unmarshal([]byte(`{"project_id": "x"}`), command))
+	assert.NotNil(json.Unmarshal([]byte(`{"production": "x"}`), environment))
+	assert.NotNil(json.Unmarshal([]byte(`{"command_ids": ["x"]}`), stage))
+}
+
+func TestFlexModelsPartial(t *testing.T) {
+	assert := assert.New(t)
+
+	// missing keys keep the fields, null clears them like encoding/json
+	command := &Command{ProjectID: Int(2)}
+	assert.Nil(json.Unmarshal([]byte(`{"command": "ls"}`), command))
+	assert.Equal(2, *command.ProjectID)
+	assert.Nil(json.Unmarshal([]byte(`{"project_id": null}`), command))
+	assert.Nil(command.ProjectID)
+
+	environment := &Environment{Production: Bool(true)}
+	assert.Nil(json.Unmarshal([]byte(`{"name": "Production"}`), environment))
+	assert.True(*environment.Production)
+	assert.Nil(json.Unmarshal([]byte(`{"production": null}`), environment))
+	assert.Nil(environment.Production)
+
+	stage := &Stage{CommandIds: []*int{Int(1)}, NextStageIds: []*int{Int(2)}}
+	assert.Nil(json.Unmarshal([]byte(`{"name": "Production"}`), stage))
+	assert.Equal([]*int{Int(1)}, stage.CommandIds)
+	assert.Equal([]*int{Int(2)}, stage.NextStageIds)
+	assert.Nil(json.Unmarshal([]byte(`{"command_ids": null}`), stage))
+	assert.Nil(stage.CommandIds)
+	assert.Equal([]*int{Int(2)}, stage.NextStageIds)
+}
+
+func TestFlexStageIds(t *testing.T) {
+	assert := assert.New(t)
+
+	stage := &Stage{}
+	data := `{"project_id": "7", "next_stage_ids": ["2", 3], "deploy_group_ids": ["4"], "template_stage_id": "5"}`
+	assert.Nil(json.Unmarshal([]byte(data), stage))
+	assert.Equal(7, *stage.ProjectID)
+	assert.Equal([]*int{Int(2), Int(3)}, stage.NextStageIds)
+	assert.Equal([]*int{Int(4)}, stage.DeployGroupIds)
+	assert.Equal(5, *stage.TemplateStageID)
+	assert.Equal(5, *stage.TamplateStageID)
+
+	assert.NotNil(json.Unmarshal([]byte(`{"next_stage_ids": ["x"]}`), stage))
+	assert.NotNil(json.Unmarshal([]byte(`{"template_stage_id": true}`), stage))
+}
@@ -0,0 +1,174 @@
+// Package samsontest provides an in memory samson serving projects,
+// stages, commands, environments and deploy groups for tests.
+package samsontest
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+// Server is an in memory samson api, requests are recorded as
+// "METHOD /path" and the request matching Fail is answered with a 422
+type Server struct {
+	mu        sync.Mutex
+	nextID    int
+	nestedID  int
+	Resources map[string]map[int]map[string]interface{}
+	Requests  []string
+	Fail      string
+}
+
+// New returns an empty server
+func New() *Server {
+	return &Server{
+		nextID:   100,
+		nestedID: 1000,
+		Resources: map[string]map[int]map[string]interface{}{
+			"projects":      {},
+			"stages":        {},
+			"commands":      {},
+			"environments":  {},
+			"deploy_groups": {},
+		},
+	}
+}
+
+// Add stores a resource given as json and returns its id
+func (f *Server) Add(collection, data string) int {
+	var resource map[string]interface{}
+	err := json.Unmarshal([]byte(data), &resource)
+	if err != nil {
+		panic(err)
+	}
+
+	id := int(resource["id"].(float64))
+	f.Resources[collection][id] = resource
+
+	return id
+}
+
+// ServeHTTP implements http.Handler
+func (f *Server) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	f.Requests = append(f.Requests, r.Method+" "+r.URL.Path)
+	if f.Fail == r.Method+" "+r.URL.Path {
+		w.WriteHeader(422)
+		fmt.Fprint(w, `{"message": "invalid"}`)
+		return
+	}
+
+	parts := strings.Split(strings.TrimSuffix(strings.TrimPrefix(r.URL.Path, "/"), ".json"), "/")
+	collection, ok := f.Resources[parts[0]]
+	if !ok {
+		w.WriteHeader(404)
+		fmt.Fprint(w, `{"message": "Not Found"}`)
+		return
+	}
+
+	var body map[string]interface{}
+	data, _ := ioutil.ReadAll(r.Body)
+	if len(data) > 0 {
+		json.Unmarshal(data, &body)
+	}
+
+	if len(parts) == 1 {
+		switch r.Method {
+		case "GET":
+			ids := make([]int, 0, len(collection))
+			for id := range collection {
+				ids = append(ids, id)
+			}
+			sort.Ints(ids)
+			list := make([]interface{}, len(ids))
+			for i, id := range ids {
+				list[i] = collection[id]
+			}
+			json.NewEncoder(w).Encode(map[string]interface{}{parts[0]: list})
+		case "POST":
+			f.nextID++
+			resource := map[string]interface{}{
+				"id":         f.nextID,
+				"created_at": "2018-03-26T11:52:01Z",
+			}
+			f.merge(resource, body)
+			collection[f.nextID] = resource
+			w.WriteHeader(201)
+			json.NewEncoder(w).Encode(resource)
+		}
+		return
+	}
+
+	id, _ := strconv.Atoi(parts[1])
+	resource, ok := collection[id]
+	if !ok {
+		w.WriteHeader(404)
+		fmt.Fprint(w, `{"message": "Not Found"}`)
+		return
+	}
+
+	switch r.Method {
+	case "GET":
+		json.NewEncoder(w).Encode(resource)
+	case "PUT":
+		f.merge(resource, body)
+		json.NewEncoder(w).Encode(resource)
+	case "DELETE":
+		delete(collection, id)
+	}
+}
+
+// merge applies attributes like rails, nested attributes are updated by
+// id, removed with _destroy and appended otherwise
+func (f *Server) merge(resource, body map[string]interface{}) {
+	for key, value := range body {
+		if key == "id" || key == "created_at" {
+			continue
+		}
+		if !strings.HasSuffix(key, "_attributes") {
+			resource[key] = value
+			continue
+		}
+
+		existing, _ := resource[key].([]interface{})
+		for _, item := range value.([]interface{}) {
+			attributes := item.(map[string]interface{})
+			id, hasID := attributes["id"]
+			if !hasID {
+				f.nestedID++
+				attributes["id"] = float64(f.nestedID)
+				existing = append(existing, attributes)
+				continue
+			}
+
+			for i, e := range existing {
+				current := e.(map[string]interface{})
+				if current["id"] != id {
+					continue
+				}
+				if attributes["_destroy"] == true {
+					existing = append(existing[:i], existing[i+1:]...)
+					break
+				}
+				for k, v := range attributes {
+					current[k] = v
+				}
+				break
+			}
+		}
+		resource[key] = existing
+	}
+}
+
+// Start serves the api until the returned server is closed
+func (f *Server) Start() *httptest.Server {
+	return httptest.NewServer(f)
+}
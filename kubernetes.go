@@ -0,0 +1,302 @@
+package samson
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"time"
+)
+
+// KubernetesService service
+type KubernetesService service
+
+// KubernetesCluster model
+// The config file and context define how Samson connects to the cluster
+type KubernetesCluster struct {
+	ID             *int       `json:"id,omitempty"`
+	Name           *string    `json:"name,omitempty"`
+	Description    *string    `json:"description,omitempty"`
+	ConfigFilepath *string    `json:"config_filepath,omitempty"`
+	ConfigContext  *string    `json:"config_context,omitempty"`
+	IPPrefix       *string    `json:"ip_prefix,omitempty"`
+	CreatedAt      *time.Time `json:"created_at,omitempty"`
+	UpdatedAt      *time.Time `json:"updated_at,omitempty"`
+}
+
+// KubernetesRole model for the kubernetes roles of a project
+type KubernetesRole struct {
+	ID           *int       `json:"id,omitempty"`
+	ProjectID    *int       `json:"project_id,omitempty"`
+	Name         *string    `json:"name,omitempty"`
+	ConfigFile   *string    `json:"config_file,omitempty"`
+	ServiceName  *string    `json:"service_name,omitempty"`
+	ResourceName *string    `json:"resource_name,omitempty"`
+	Autoscaled   *bool      `json:"autoscaled,omitempty"`
+	BlueGreen    *bool      `json:"blue_green,omitempty"`
+	CreatedAt    *time.Time `json:"created_at,omitempty"`
+	UpdatedAt    *time.Time `json:"updated_at,omitempty"`
+	DeletedAt    *time.Time `json:"deleted_at,omitempty"`
+}
+
+// KubernetesDeployGroupRole model for the settings of a kubernetes role in a deploy group
+type KubernetesDeployGroupRole struct {
+	ID               *int       `json:"id,omitempty"`
+	ProjectID        *int       `json:"project_id,omitempty"`
+	DeployGroupID    *int       `json:"deploy_group_id,omitempty"`
+	KubernetesRoleID *int       `json:"kubernetes_role_id,omitempty"`
+	Replicas         *int       `json:"replicas,omitempty"`
+	RequestsCPU      *CPU       `json:"requests_cpu,omitempty"`
+	RequestsMemory   *Memory    `json:"requests_memory,omitempty"`
+	LimitsCPU        *CPU       `json:"limits_cpu,omitempty"`
+	LimitsMemory     *Memory    `json:"limits_memory,omitempty"`
+	NoCPULimit       *bool      `json:"no_cpu_limit,omitempty"`
+	DeleteResource   *bool      `json:"delete_resource,omitempty"`
+	CreatedAt        *time.Time `json:"created_at,omitempty"`
+	UpdatedAt        *time.Time `json:"updated_at,omitempty"`
+}
+
+// ListClusters returns all kubernetes clusters
+func (service *KubernetesService) ListClusters() ([]*KubernetesCluster, *Call, error) {
+	path := "/kubernetes/clusters.json"
+	method := "GET"
+
+	call, err := service.s.NewCall(method, path, nil, nil, nil)
+	if err != nil {
+		return nil, call, err
+	}
+
+	type response struct {
+		Clusters []*KubernetesCluster `json:"kubernetes_clusters,omitempty"`
+	}
+	var res response
+	err = call.Do(&res)
+	if err != nil {
+		return nil, call, err
+	}
+
+	return res.Clusters, call, nil
+}
+
+// GetCluster returns a single kubernetes cluster resource
+func (service *KubernetesService) GetCluster(id int) (*KubernetesCluster, *Call, error) {
+	path := fmt.Sprintf("/kubernetes/clusters/%d.json", id)
+	method := "GET"
+
+	call, err := service.s.NewCall(method, path, nil, nil, nil)
+	if err != nil {
+		return nil, call, err
+	}
+
+	var cluster KubernetesCluster
+	err = call.Do(&cluster)
+	if err != nil {
+		return nil, call, err
+	}
+
+	return &cluster, call, nil
+}
+
+// UpsertCluster updates or creates a new kubernetes cluster resource
+func (service *KubernetesService) UpsertCluster(cluster *KubernetesCluster) (*KubernetesCluster, *Call, error) {
+	bytesArray, _ := json.Marshal(cluster)
+
+	var path string
+	var method string
+
+	if cluster.ID != nil {
+		path = fmt.Sprintf("/kubernetes/clusters/%d.json", *cluster.ID)
+		method = "PUT"
+	} else {
+		path = "/kubernetes/clusters.json"
+		method = "POST"
+	}
+
+	call, err := service.s.NewCall(method, path, nil, nil, bytes.NewReader(bytesArray))
+	if err != nil {
+		return nil, call, err
+	}
+
+	err = call.Do(&cluster)
+	if err != nil {
+		return nil, call, err
+	}
+
+	return cluster, call, nil
+}
+
+// DeleteCluster deletes a sinlge kubernetes cluster resource
+func (service *KubernetesService) DeleteCluster(id int) (*Call, error) {
+	path := fmt.Sprintf("/kubernetes/clusters/%d.json", id)
+	method := "DELETE"
+
+	call, err := service.s.NewCall(method, path, nil, nil, nil)
+	if err != nil {
+		return call, err
+	}
+
+	return call, call.Do(nil)
+}
+
+// ListRoles returns the kubernetes roles of a project
+func (service *KubernetesService) ListRoles(projectID int) ([]*KubernetesRole, *Call, error) {
+	path := fmt.Sprintf("/projects/%d/kubernetes/roles.json", projectID)
+	method := "GET"
+
+	call, err := service.s.NewCall(method, path, nil, nil, nil)
+	if err != nil {
+		return nil, call, err
+	}
+
+	type response struct {
+		Roles []*KubernetesRole `json:"kubernetes_roles,omitempty"`
+	}
+	var res response
+	err = call.Do(&res)
+	if err != nil {
+		return nil, call, err
+	}
+
+	return res.Roles, call, nil
+}
+
+// GetRole returns a single kubernetes role of a project
+func (service *KubernetesService) GetRole(projectID int, id int) (*KubernetesRole, *Call, error) {
+	path := fmt.Sprintf("/projects/%d/kubernetes/roles/%d.json", projectID, id)
+	method := "GET"
+
+	call, err := service.s.NewCall(method, path, nil, nil, nil)
+	if err != nil {
+		return nil, call, err
+	}
+
+	var role KubernetesRole
+	err = call.Do(&role)
+	if err != nil {
+		return nil, call, err
+	}
+
+	return &role, call, nil
+}
+
+// UpsertRole updates or creates a new kubernetes role of a project
+func (service *KubernetesService) UpsertRole(projectID int, role *KubernetesRole) (*KubernetesRole, *Call, error) {
+	bytesArray, _ := json.Marshal(role)
+
+	var path string
+	var method string
+
+	if role.ID != nil {
+		path = fmt.Sprintf("/projects/%d/kubernetes/roles/%d.json", projectID, *role.ID)
+		method = "PUT"
+	} else {
+		path = fmt.Sprintf("/projects/%d/kubernetes/roles.json", projectID)
+		method = "POST"
+	}
+
+	call, err := service.s.NewCall(method, path, nil, nil, bytes.NewReader(bytesArray))
+	if err != nil {
+		return nil, call, err
+	}
+
+	err = call.Do(&role)
+	if err != nil {
+		return nil, call, err
+	}
+
+	return role, call, nil
+}
+
+// DeleteRole deletes a sinlge kubernetes role of a project
+func (service *KubernetesService) DeleteRole(projectID int, id int) (*Call, error) {
+	path := fmt.Sprintf("/projects/%d/kubernetes/roles/%d.json", projectID, id)
+	method := "DELETE"
+
+	call, err := service.s.NewCall(method, path, nil, nil, nil)
+	if err != nil {
+		return call, err
+	}
+
+	return call, call.Do(nil)
+}
+
+// ListDeployGroupRoles returns the deploy group role settings of a project
+func (service *KubernetesService) ListDeployGroupRoles(projectID int) ([]*KubernetesDeployGroupRole, *Call, error) {
+	path := fmt.Sprintf("/projects/%d/kubernetes/deploy_group_roles.json", projectID)
+	method := "GET"
+
+	call, err := service.s.NewCall(method, path, nil, nil, nil)
+	if err != nil {
+		return nil, call, err
+	}
+
+	type response struct {
+		DeployGroupRoles []*KubernetesDeployGroupRole `json:"kubernetes_deploy_group_roles,omitempty"`
+	}
+	var res response
+	err = call.Do(&res)
+	if err != nil {
+		return nil, call, err
+	}
+
+	return res.DeployGroupRoles, call, nil
+}
+
+// GetDeployGroupRole returns a single deploy group role setting of a project
+func (service *KubernetesService) GetDeployGroupRole(projectID int, id int) (*KubernetesDeployGroupRole, *Call, error) {
+	path := fmt.Sprintf("/projects/%d/kubernetes/deploy_group_roles/%d.json", projectID, id)
+	method := "GET"
+
+	call, err := service.s.NewCall(method, path, nil, nil, nil)
+	if err != nil {
+		return nil, call, err
+	}
+
+	var deployGroupRole KubernetesDeployGroupRole
+	err = call.Do(&deployGroupRole)
+	if err != nil {
+		return nil, call, err
+	}
+
+	return &deployGroupRole, call, nil
+}
+
+// UpsertDeployGroupRole updates or creates a new deploy group role setting of a project
+func (service *KubernetesService) UpsertDeployGroupRole(projectID int, deployGroupRole *KubernetesDeployGroupRole) (*KubernetesDeployGroupRole, *Call, error) {
+	bytesArray, _ := json.Marshal(deployGroupRole)
+
+	var path string
+	var method string
+
+	if deployGroupRole.ID != nil {
+		path = fmt.Sprintf("/projects/%d/kubernetes/deploy_group_roles/%d.json", projectID, *deployGroupRole.ID)
+		method = "PUT"
+	} else {
+		path = fmt.Sprintf("/projects/%d/kubernetes/deploy_group_roles.json", projectID)
+		method = "POST"
+	}
+
+	call, err := service.s.NewCall(method, path, nil, nil, bytes.NewReader(bytesArray))
+	if err != nil {
+		return nil, call, err
+	}
+
+	err = call.Do(&deployGroupRole)
+	if err != nil {
+		return nil, call, err
+	}
+
+	return deployGroupRole, call, nil
+}
+
+// DeleteDeployGroupRole deletes a sinlge deploy group role setting of a project
+func (service *KubernetesService) DeleteDeployGroupRole(projectID int, id int) (*Call, error) {
+	path := fmt.Sprintf("/projects/%d/kubernetes/deploy_group_roles/%d.json", projectID, id)
+	method := "DELETE"
+
+	call, err := service.s.NewCall(method, path, nil, nil, nil)
+	if err != nil {
+		return call, err
+	}
+
+	return call, call.Do(nil)
+}
@@ -0,0 +1,384 @@
+package samson
+
+import (
+	"encoding/json"
+	"fmt"
+	"log"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func ExampleKubernetesService_UpsertDeployGroupRole() {
+	client := New("token")
+
+	cpu, _ := ParseCPU("500m")
+	memory, _ := ParseMemory("512Mi")
+
+	deployGroupRole := &KubernetesDeployGroupRole{
+		DeployGroupID:    Int(1),
+		KubernetesRoleID: Int(1),
+		Replicas:         Int(3),
+		RequestsCPU:      &cpu,
+		RequestsMemory:   &memory,
+	}
+
+	_, _, err := client.Kubernetes.UpsertDeployGroupRole(2, deployGroupRole)
+	if err != nil {
+		log.Fatal(err)
+	}
+}
+
+func kubernetesTestServer(assert *assert.Assertions, method, path, fixture string, checkPayload func(map[string]interface{})) *httptest.Server {
+	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(method, r.Method)
+		assert.Equal(path, r.URL.Path)
+		checkHeaders(r, assert)
+
+		if checkPayload != nil {
+			var payload map[string]interface{}
+			err := json.NewDecoder(r.Body).Decode(&payload)
+			assert.Nil(err)
+			checkPayload(payload)
+		}
+
+		w.WriteHeader(200)
+		if fixture != "" {
+			fmt.Fprintln(w, readTestData(fixture))
+		}
+	})
+
+	return httptest.NewServer(handler)
+}
+
+func TestKubernetesServiceListClusters(t *testing.T) {
+	var err error
+	assert := assert.New(t)
+
+	server := kubernetesTestServer(assert, "GET", "/kubernetes/clusters.json", "kubernetes_clusters.json", nil)
+	defer server.Close()
+
+	client = New(token)
+	client.BaseURL = server.URL
+
+	clusters, call, err := client.Kubernetes.ListClusters()
+	assert.Nil(err)
+	assert.IsType(&Call{}, call)
+	assert.Equal(1, len(clusters))
+	assert.Equal("us-east-1", *clusters[0].ConfigContext)
+}
+
+func TestKubernetesServiceListClusters_fail(t *testing.T) {
+	var err error
+	assert := assert.New(t)
+
+	client = New(token)
+	client.BaseURL = "^http://localhost"
+
+	clusters, call, err := client.Kubernetes.ListClusters()
+	assert.NotNil(err)
+	assert.IsType(&Call{}, call)
+	assert.Nil(clusters)
+}
+
+func TestKubernetesServiceGetCluster(t *testing.T) {
+	var err error
+	assert := assert.New(t)
+
+	server := kubernetesTestServer(assert, "GET", "/kubernetes/clusters/1.json", "kubernetes_cluster.json", nil)
+	defer server.Close()
+
+	client = New(token)
+	client.BaseURL = server.URL
+
+	cluster, call, err := client.Kubernetes.GetCluster(1)
+	assert.Nil(err)
+	assert.IsType(&Call{}, call)
+	assert.Equal("/etc/kubernetes/config", *cluster.ConfigFilepath)
+}
+
+func TestKubernetesServiceUpsertCluster(t *testing.T) {
+	var err error
+	assert := assert.New(t)
+
+	server := kubernetesTestServer(assert, "POST", "/kubernetes/clusters.json", "kubernetes_cluster.json", func(payload map[string]interface{}) {
+		assert.Equal("us-east-1", payload["name"])
+		assert.Equal("/etc/kubernetes/config", payload["config_filepath"])
+	})
+
+	client = New(token)
+	client.BaseURL = server.URL
+
+	cluster := &KubernetesCluster{
+		Name:           String("us-east-1"),
+		ConfigFilepath: String("/etc/kubernetes/config"),
+	}
+
+	cluster, call, err := client.Kubernetes.UpsertCluster(cluster)
+	assert.Nil(err)
+	assert.IsType(&Call{}, call)
+	assert.Equal(1, *cluster.ID)
+	server.Close()
+
+	server = kubernetesTestServer(assert, "PUT", "/kubernetes/clusters/1.json", "kubernetes_cluster.json", func(payload map[string]interface{}) {
+		assert.Equal("primary cluster", payload["description"])
+	})
+	defer server.Close()
+	client.BaseURL = server.URL
+
+	cluster.Description = String("primary cluster")
+	_, _, err = client.Kubernetes.UpsertCluster(cluster)
+	assert.Nil(err)
+}
+
+func TestKubernetesServiceUpsertCluster_fail(t *testing.T) {
+	var err error
+	assert := assert.New(t)
+
+	client = New(token)
+	client.BaseURL = "^http://localhost"
+
+	cluster, call, err := client.Kubernetes.UpsertCluster(&KubernetesCluster{})
+	assert.NotNil(err)
+	assert.IsType(&Call{}, call)
+	assert.Nil(cluster)
+}
+
+func TestKubernetesServiceDeleteCluster(t *testing.T) {
+	var err error
+	assert := assert.New(t)
+
+	server := kubernetesTestServer(assert, "DELETE", "/kubernetes/clusters/1.json", "", nil)
+	defer server.Close()
+
+	client = New(token)
+	client.BaseURL = server.URL
+
+	call, err := client.Kubernetes.DeleteCluster(1)
+	assert.Nil(err)
+	assert.IsType(&Call{}, call)
+}
+
+func TestKubernetesServiceListRoles(t *testing.T) {
+	var err error
+	assert := assert.New(t)
+
+	server := kubernetesTestServer(assert, "GET", "/projects/2/kubernetes/roles.json", "kubernetes_roles.json", nil)
+	defer server.Close()
+
+	client = New(token)
+	client.BaseURL = server.URL
+
+	roles, call, err := client.Kubernetes.ListRoles(2)
+	assert.Nil(err)
+	assert.IsType(&Call{}, call)
+	assert.Equal(2, len(roles))
+	assert.Nil(roles[1].ServiceName)
+	assert.Equal("example-kubernetes-worker", *roles[1].ResourceName)
+}
+
+func TestKubernetesServiceListRoles_fail(t *testing.T) {
+	var err error
+	assert := assert.New(t)
+
+	client = New(token)
+	client.BaseURL = "^http://localhost"
+
+	roles, call, err := client.Kubernetes.ListRoles(2)
+	assert.NotNil(err)
+	assert.IsType(&Call{}, call)
+	assert.Nil(roles)
+}
+
+func TestKubernetesServiceGetRole(t *testing.T) {
+	var err error
+	assert := assert.New(t)
+
+	server := kubernetesTestServer(assert, "GET", "/projects/2/kubernetes/roles/1.json", "kubernetes_role.json", nil)
+	defer server.Close()
+
+	client = New(token)
+	client.BaseURL = server.URL
+
+	role, call, err := client.Kubernetes.GetRole(2, 1)
+	assert.Nil(err)
+	assert.IsType(&Call{}, call)
+	assert.Equal("kubernetes/app_server.yml", *role.ConfigFile)
+}
+
+func TestKubernetesServiceUpsertRole(t *testing.T) {
+	var err error
+	assert := assert.New(t)
+
+	server := kubernetesTestServer(assert, "POST", "/projects/2/kubernetes/roles.json", "kubernetes_role.json", func(payload map[string]interface{}) {
+		assert.Equal("app-server", payload["name"])
+		assert.Equal("kubernetes/app_server.yml", payload["config_file"])
+		assert.Equal("app-server", payload["service_name"])
+		assert.Equal("example-kubernetes-app-server", payload["resource_name"])
+	})
+
+	client = New(token)
+	client.BaseURL = server.URL
+
+	role := &KubernetesRole{
+		Name:         String("app-server"),
+		ConfigFile:   String("kubernetes/app_server.yml"),
+		ServiceName:  String("app-server"),
+		ResourceName: String("example-kubernetes-app-server"),
+	}
+
+	role, call, err := client.Kubernetes.UpsertRole(2, role)
+	assert.Nil(err)
+	assert.IsType(&Call{}, call)
+	assert.Equal(1, *role.ID)
+	server.Close()
+
+	server = kubernetesTestServer(assert, "PUT", "/projects/2/kubernetes/roles/1.json", "kubernetes_role.json", nil)
+	defer server.Close()
+	client.BaseURL = server.URL
+
+	_, _, err = client.Kubernetes.UpsertRole(2, role)
+	assert.Nil(err)
+}
+
+func TestKubernetesServiceUpsertRole_fail(t *testing.T) {
+	var err error
+	assert := assert.New(t)
+
+	client = New(token)
+	client.BaseURL = "^http://localhost"
+
+	role, call, err := client.Kubernetes.UpsertRole(2, &KubernetesRole{})
+	assert.NotNil(err)
+	assert.IsType(&Call{}, call)
+	assert.Nil(role)
+}
+
+func TestKubernetesServiceDeleteRole(t *testing.T) {
+	var err error
+	assert := assert.New(t)
+
+	server := kubernetesTestServer(assert, "DELETE", "/projects/2/kubernetes/roles/1.json", "", nil)
+	defer server.Close()
+
+	client = New(token)
+	client.BaseURL = server.URL
+
+	call, err := client.Kubernetes.DeleteRole(2, 1)
+	assert.Nil(err)
+	assert.IsType(&Call{}, call)
+}
+
+func TestKubernetesServiceListDeployGroupRoles(t *testing.T) {
+	var err error
+	assert := assert.New(t)
+
+	server := kubernetesTestServer(assert, "GET", "/projects/2/kubernetes/deploy_group_roles.json", "kubernetes_deploy_group_roles.json", nil)
+	defer server.Close()
+
+	client = New(token)
+	client.BaseURL = server.URL
+
+	deployGroupRoles, call, err := client.Kubernetes.ListDeployGroupRoles(2)
+	assert.Nil(err)
+	assert.IsType(&Call{}, call)
+	assert.Equal(2, len(deployGroupRoles))
+
+	assert.Equal(CPU(0.25), *deployGroupRoles[0].RequestsCPU)
+	assert.Equal(512*Mebibyte, *deployGroupRoles[0].RequestsMemory)
+	assert.Equal("250m", deployGroupRoles[0].RequestsCPU.String())
+	assert.Equal("1Gi", deployGroupRoles[0].LimitsMemory.String())
+
+	assert.Equal(CPU(0.5), *deployGroupRoles[1].RequestsCPU)
+	assert.Equal(256*Mebibyte, *deployGroupRoles[1].RequestsMemory)
+	assert.Equal(CPU(2), *deployGroupRoles[1].LimitsCPU)
+	assert.Equal(Gibibyte, *deployGroupRoles[1].LimitsMemory)
+}
+
+func TestKubernetesServiceListDeployGroupRoles_fail(t *testing.T) {
+	var err error
+	assert := assert.New(t)
+
+	client = New(token)
+	client.BaseURL = "^http://localhost"
+
+	deployGroupRoles, call, err := client.Kubernetes.ListDeployGroupRoles(2)
+	assert.NotNil(err)
+	assert.IsType(&Call{}, call)
+	assert.Nil(deployGroupRoles)
+}
+
+func TestKubernetesServiceGetDeployGroupRole(t *testing.T) {
+	var err error
+	assert := assert.New(t)
+
+	server := kubernetesTestServer(assert, "GET", "/projects/2/kubernetes/deploy_group_roles/1.json", "kubernetes_deploy_group_role.json", nil)
+	defer server.Close()
+
+	client = New(token)
+	client.BaseURL = server.URL
+
+	deployGroupRole, call, err := client.Kubernetes.GetDeployGroupRole(2, 1)
+	assert.Nil(err)
+	assert.IsType(&Call{}, call)
+	assert.Equal(3, *deployGroupRole.Replicas)
+}
+
+func TestKubernetesServiceUpsertDeployGroupRole(t *testing.T) {
+	var err error
+	assert := assert.New(t)
+
+	server := kubernetesTestServer(assert, "PUT", "/projects/2/kubernetes/deploy_group_roles/1.json", "kubernetes_deploy_group_role.json", func(payload map[string]interface{}) {
+		assert.Equal(float64(3), payload["replicas"])
+		assert.Equal(0.5, payload["requests_cpu"])
+		assert.Equal(float64(512), payload["requests_memory"])
+	})
+	defer server.Close()
+
+	client = New(token)
+	client.BaseURL = server.URL
+
+	cpu := CPU(0.5)
+	memory := 512 * Mebibyte
+	deployGroupRole := &KubernetesDeployGroupRole{
+		ID:             Int(1),
+		Replicas:       Int(3),
+		RequestsCPU:    &cpu,
+		RequestsMemory: &memory,
+	}
+
+	deployGroupRole, call, err := client.Kubernetes.UpsertDeployGroupRole(2, deployGroupRole)
+	assert.Nil(err)
+	assert.IsType(&Call{}, call)
+	assert.Equal(CPU(0.25), *deployGroupRole.RequestsCPU)
+}
+
+func TestKubernetesServiceUpsertDeployGroupRole_fail(t *testing.T) {
+	var err error
+	assert := assert.New(t)
+
+	client = New(token)
+	client.BaseURL = "^http://localhost"
+
+	deployGroupRole, call, err := client.Kubernetes.UpsertDeployGroupRole(2, &KubernetesDeployGroupRole{})
+	assert.NotNil(err)
+	assert.IsType(&Call{}, call)
+	assert.Nil(deployGroupRole)
+}
+
+func TestKubernetesServiceDeleteDeployGroupRole(t *testing.T) {
+	var err error
+	assert := assert.New(t)
+
+	server := kubernetesTestServer(assert, "DELETE", "/projects/2/kubernetes/deploy_group_roles/1.json", "", nil)
+	defer server.Close()
+
+	client = New(token)
+	client.BaseURL = server.URL
+
+	call, err := client.Kubernetes.DeleteDeployGroupRole(2, 1)
+	assert.Nil(err)
+	assert.IsType(&Call{}, call)
+}
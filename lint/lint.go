@@ -0,0 +1,254 @@
+// Package lint checks projects, stages and commands against policy rules
+// such as "production stages require confirmation" and reports the
+// violations with a severity.
+//
+// Rules run over a backup archive, so a live instance is linted through
+// backup.Export and a snapshot through backup.Read. Builtin returns the
+// default rule set, custom rules implement Rule or are built with
+// ProjectRule, StageRule and CommandRule.
+package lint
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"sort"
+	"strconv"
+	"strings"
+
+	samson "github.com/tolgaakyuz/samson-go"
+	"github.com/tolgaakyuz/samson-go/backup"
+)
+
+// Severity of a finding
+type Severity int
+
+// Severities
+const (
+	Info Severity = iota
+	Warning
+	Error
+)
+
+var severityNames = []string{"info", "warning", "error"}
+
+func (s Severity) String() string {
+	if s < Info || s > Error {
+		return fmt.Sprintf("Severity(%d)", int(s))
+	}
+
+	return severityNames[s]
+}
+
+// MarshalJSON encodes the severity by name
+func (s Severity) MarshalJSON() ([]byte, error) {
+	return json.Marshal(s.String())
+}
+
+// UnmarshalJSON decodes a severity name
+func (s *Severity) UnmarshalJSON(data []byte) error {
+	var name string
+	err := json.Unmarshal(data, &name)
+	if err != nil {
+		return err
+	}
+
+	*s, err = ParseSeverity(name)
+	return err
+}
+
+// ParseSeverity returns the severity with the given name
+func ParseSeverity(name string) (Severity, error) {
+	for i, severityName := range severityNames {
+		if name == severityName {
+			return Severity(i), nil
+		}
+	}
+
+	return Info, fmt.Errorf("unknown severity %q, expected one of %s", name, strings.Join(severityNames, ", "))
+}
+
+// Finding is a violation of a rule by a resource
+// Resources are named like in drift reports: projects and stages by
+// permalink, commands by their quoted text
+type Finding struct {
+	Rule     string   `json:"rule"`
+	Severity Severity `json:"severity"`
+	Resource string   `json:"resource"`
+	Name     string   `json:"name"`
+	Message  string   `json:"message"`
+}
+
+func (f Finding) String() string {
+	return fmt.Sprintf("%s %s: %s (%s)", f.Resource, f.Name, f.Message, f.Rule)
+}
+
+// Rule checks the resources of an archive
+type Rule interface {
+	// Name identifies the rule in findings, e.g. production-confirm
+	Name() string
+	Check(archive *Archive) []Finding
+}
+
+// Archive is the linted archive with lookups of its resources by id
+type Archive struct {
+	*backup.Archive
+
+	projects map[int]*samson.Project
+	commands map[int]*samson.Command
+	stages   map[int]*samson.Stage
+}
+
+func newArchive(archive *backup.Archive) *Archive {
+	a := &Archive{
+		Archive:  archive,
+		projects: map[int]*samson.Project{},
+		commands: map[int]*samson.Command{},
+		stages:   map[int]*samson.Stage{},
+	}
+	for _, project := range archive.Projects {
+		a.projects[id(project.ID)] = project
+	}
+	for _, command := range archive.Commands {
+		a.commands[id(command.ID)] = command
+	}
+	for _, stage := range archive.Stages {
+		a.stages[id(stage.ID)] = stage
+	}
+
+	return a
+}
+
+// Project returns the project with the id, nil when it isn't archived
+func (a *Archive) Project(projectID int) *samson.Project {
+	return a.projects[projectID]
+}
+
+// Command returns the command with the id, nil when it isn't archived
+func (a *Archive) Command(commandID int) *samson.Command {
+	return a.commands[commandID]
+}
+
+// Stage returns the stage with the id, nil when it isn't archived
+func (a *Archive) Stage(stageID int) *samson.Stage {
+	return a.stages[stageID]
+}
+
+// ProjectName names a project by permalink
+func (a *Archive) ProjectName(project *samson.Project) string {
+	return str(project.Permalink)
+}
+
+// StageName names a stage by project and stage permalink
+func (a *Archive) StageName(stage *samson.Stage) string {
+	project := fmt.Sprintf("#%d", id(stage.ProjectID))
+	if p := a.Project(id(stage.ProjectID)); p != nil {
+		project = a.ProjectName(p)
+	}
+
+	return project + "/" + str(stage.Permalink)
+}
+
+// CommandName names a command by its text, prefixed by the project
+// permalink for project commands
+func (a *Archive) CommandName(command *samson.Command) string {
+	name := strconv.Quote(str(command.Command))
+	if command.ProjectID == nil {
+		return name
+	}
+
+	project := fmt.Sprintf("#%d", *command.ProjectID)
+	if p := a.Project(*command.ProjectID); p != nil {
+		project = a.ProjectName(p)
+	}
+
+	return project + "/" + name
+}
+
+// Run checks the archive against the rules
+func Run(archive *backup.Archive, rules []Rule) *Report {
+	a := newArchive(archive)
+
+	report := &Report{Findings: []Finding{}}
+	for _, rule := range rules {
+		report.Findings = append(report.Findings, rule.Check(a)...)
+	}
+
+	resources := map[string]int{"project": 0, "stage": 1, "command": 2}
+	sort.SliceStable(report.Findings, func(i, j int) bool {
+		a, b := report.Findings[i], report.Findings[j]
+		if a.Resource != b.Resource {
+			return resources[a.Resource] < resources[b.Resource]
+		}
+		return a.Name < b.Name
+	})
+
+	return report
+}
+
+// Report lists the findings ordered by resource and name
+type Report struct {
+	Findings []Finding `json:"findings"`
+}
+
+// Count returns the number of findings of the severity
+func (report *Report) Count(severity Severity) int {
+	count := 0
+	for _, finding := range report.Findings {
+		if finding.Severity == severity {
+			count++
+		}
+	}
+
+	return count
+}
+
+// Failed returns whether there are findings of the severity or above
+func (report *Report) Failed(severity Severity) bool {
+	for _, finding := range report.Findings {
+		if finding.Severity >= severity {
+			return true
+		}
+	}
+
+	return false
+}
+
+// Write prints the report in a human readable form
+func (report *Report) Write(w io.Writer) error {
+	if len(report.Findings) == 0 {
+		_, err := fmt.Fprintln(w, "No findings.")
+		return err
+	}
+
+	for _, finding := range report.Findings {
+		fmt.Fprintf(w, "%-7s %s\n", finding.Severity, finding)
+	}
+
+	_, err := fmt.Fprintf(w, "\n%d errors, %d warnings, %d info.\n", report.Count(Error), report.Count(Warning), report.Count(Info))
+	return err
+}
+
+// WriteJSON prints the report as json
+func (report *Report) WriteJSON(w io.Writer) error {
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+
+	return encoder.Encode(report)
+}
+
+func id(i *int) int {
+	if i == nil {
+		return 0
+	}
+
+	return *i
+}
+
+func str(s *string) string {
+	if s == nil {
+		return ""
+	}
+
+	return *s
+}
@@ -0,0 +1,125 @@
+package lint
+
+import (
+	"bytes"
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	samson "github.com/tolgaakyuz/samson-go"
+	"github.com/tolgaakyuz/samson-go/backup"
+)
+
+const snapshot = `{"version": 1,
+	"commands": [
+		{"id": 20, "command": "./notify.sh"},
+		{"id": 21, "command": " ", "project_id": 7},
+		{"id": 22, "command": "bundle exec cap deploy", "project_id": 7},
+		{"id": 23, "command": "make", "project_id": 8}
+	],
+	"projects": [
+		{"id": 7, "name": "Example", "permalink": "example", "build_command_id": 23},
+		{"id": 8, "name": "Other", "permalink": "other"}
+	],
+	"stages": [
+		{"id": 30, "name": "Production", "permalink": "production", "project_id": 7, "production": true,
+			"command_ids": [22, 99], "kubernetes": true, "run_in_parallel": true,
+			"slack_webhooks_attributes": [{"webhook_url": "https://hooks.slack.com/x", "channel": "deploys", "before_deploy": true}]},
+		{"id": 31, "name": "Staging", "permalink": "staging", "project_id": 7},
+		{"id": 32, "name": "Production", "permalink": "production", "project_id": 8, "production": true, "confirm": true,
+			"command_ids": [23, 20],
+			"slack_webhooks_attributes": [{"webhook_url": "https://hooks.slack.com/x", "channel": "deploys", "after_deploy": true, "only_on_failure": true}]}
+	]}`
+
+func read(t *testing.T, data string) *backup.Archive {
+	archive, err := backup.Read(strings.NewReader(data))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return archive
+}
+
+func TestBuiltin(t *testing.T) {
+	assert := assert.New(t)
+
+	report := Run(read(t, snapshot), Builtin())
+
+	var findings []string
+	for _, finding := range report.Findings {
+		findings = append(findings, finding.Severity.String()+" "+finding.String())
+	}
+	assert.Equal([]string{
+		`error project example: build command other/"make" belongs to another project (unknown-command)`,
+		`error stage example/production: production stage does not require confirmation (production-confirm)`,
+		`warning stage example/production: production stage does not notify a slack channel about failed deploys (production-slack-failure)`,
+		`error stage example/production: kubernetes stage runs in parallel (kubernetes-parallel)`,
+		`error stage example/production: command 99 does not exist (unknown-command)`,
+		`warning stage example/staging: stage runs no commands (stage-commands)`,
+		`error command example/" ": command is blank (empty-command)`,
+	}, findings)
+
+	assert.Equal(5, report.Count(Error))
+	assert.Equal(2, report.Count(Warning))
+	assert.True(report.Failed(Error))
+
+	var buf bytes.Buffer
+	assert.Nil(report.Write(&buf))
+	assert.Contains(buf.String(), "error   stage example/production: kubernetes stage runs in parallel (kubernetes-parallel)\n")
+	assert.Contains(buf.String(), "warning stage example/staging: stage runs no commands (stage-commands)\n")
+	assert.Contains(buf.String(), "\n5 errors, 2 warnings, 0 info.\n")
+}
+
+func TestCustomRules(t *testing.T) {
+	assert := assert.New(t)
+
+	rules := []Rule{
+		ProjectRule("project-owner", Info, func(a *Archive, project *samson.Project) string {
+			if project.Owner == nil {
+				return "project has no owner"
+			}
+			return ""
+		}),
+	}
+
+	report := Run(read(t, snapshot), rules)
+	assert.Len(report.Findings, 2)
+	assert.Equal(Finding{Rule: "project-owner", Severity: Info, Resource: "project", Name: "example", Message: "project has no owner"}, report.Findings[0])
+	assert.False(report.Failed(Warning))
+	assert.True(report.Failed(Info))
+}
+
+func TestReportJSON(t *testing.T) {
+	assert := assert.New(t)
+
+	var buf bytes.Buffer
+	report := Run(read(t, `{"version": 1}`), Builtin())
+	assert.Nil(report.WriteJSON(&buf))
+	assert.Equal("{\n  \"findings\": []\n}\n", buf.String())
+
+	buf.Reset()
+	assert.Nil(report.Write(&buf))
+	assert.Equal("No findings.\n", buf.String())
+
+	buf.Reset()
+	report = Run(read(t, snapshot), Builtin())
+	assert.Nil(report.WriteJSON(&buf))
+
+	var decoded Report
+	assert.Nil(json.Unmarshal(buf.Bytes(), &decoded))
+	assert.Equal(report, &decoded)
+	assert.Contains(buf.String(), `"severity": "warning"`)
+}
+
+func TestParseSeverity(t *testing.T) {
+	assert := assert.New(t)
+
+	severity, err := ParseSeverity("warning")
+	assert.Nil(err)
+	assert.Equal(Warning, severity)
+
+	_, err = ParseSeverity("fatal")
+	assert.EqualError(err, `unknown severity "fatal", expected one of info, warning, error`)
+	assert.Equal("Severity(7)", Severity(7).String())
+}
@@ -0,0 +1,161 @@
+package lint
+
+import (
+	"strconv"
+	"strings"
+
+	samson "github.com/tolgaakyuz/samson-go"
+)
+
+// Builtin returns the default rules
+//
+//	production-confirm        error    production stages require deploy confirmation
+//	production-slack-failure  warning  production stages notify a slack channel about failed deploys
+//	kubernetes-parallel       error    kubernetes stages don't run in parallel
+//	stage-commands            warning  stages run at least one command
+//	unknown-command           error    stages and builds only use existing global or own project commands
+//	empty-command             error    commands aren't blank
+func Builtin() []Rule {
+	return []Rule{
+		StageRule("production-confirm", Error, func(a *Archive, stage *samson.Stage) string {
+			if isTrue(stage.Production) && !isTrue(stage.Confirm) {
+				return "production stage does not require confirmation"
+			}
+			return ""
+		}),
+		StageRule("production-slack-failure", Warning, func(a *Archive, stage *samson.Stage) string {
+			if !isTrue(stage.Production) {
+				return ""
+			}
+			for _, webhook := range stage.SlackWebhookAtrributes {
+				if !isTrue(webhook.Destroy) && str(webhook.WebhookURL) != "" && isTrue(webhook.AfterDeploy) {
+					return ""
+				}
+			}
+			return "production stage does not notify a slack channel about failed deploys"
+		}),
+		StageRule("kubernetes-parallel", Error, func(a *Archive, stage *samson.Stage) string {
+			if isTrue(stage.Kubernetes) && isTrue(stage.RunInParallel) {
+				return "kubernetes stage runs in parallel"
+			}
+			return ""
+		}),
+		StageRule("stage-commands", Warning, func(a *Archive, stage *samson.Stage) string {
+			if len(stage.CommandIds) == 0 && strings.TrimSpace(str(stage.Command)) == "" {
+				return "stage runs no commands"
+			}
+			return ""
+		}),
+		unknownCommandRule{},
+		CommandRule("empty-command", Error, func(a *Archive, command *samson.Command) string {
+			if strings.TrimSpace(str(command.Command)) == "" {
+				return "command is blank"
+			}
+			return ""
+		}),
+	}
+}
+
+// ProjectRule returns a rule checking every project, check returns the
+// message of a violation or an empty string
+func ProjectRule(name string, severity Severity, check func(a *Archive, project *samson.Project) string) Rule {
+	return funcRule{name: name, check: func(a *Archive) []Finding {
+		var findings []Finding
+		for _, project := range a.Projects {
+			if message := check(a, project); message != "" {
+				findings = append(findings, Finding{Rule: name, Severity: severity, Resource: "project", Name: a.ProjectName(project), Message: message})
+			}
+		}
+		return findings
+	}}
+}
+
+// StageRule returns a rule checking every stage, check returns the
+// message of a violation or an empty string
+func StageRule(name string, severity Severity, check func(a *Archive, stage *samson.Stage) string) Rule {
+	return funcRule{name: name, check: func(a *Archive) []Finding {
+		var findings []Finding
+		for _, stage := range a.Stages {
+			if message := check(a, stage); message != "" {
+				findings = append(findings, Finding{Rule: name, Severity: severity, Resource: "stage", Name: a.StageName(stage), Message: message})
+			}
+		}
+		return findings
+	}}
+}
+
+// CommandRule returns a rule checking every command, check returns the
+// message of a violation or an empty string
+func CommandRule(name string, severity Severity, check func(a *Archive, command *samson.Command) string) Rule {
+	return funcRule{name: name, check: func(a *Archive) []Finding {
+		var findings []Finding
+		for _, command := range a.Commands {
+			if message := check(a, command); message != "" {
+				findings = append(findings, Finding{Rule: name, Severity: severity, Resource: "command", Name: a.CommandName(command), Message: message})
+			}
+		}
+		return findings
+	}}
+}
+
+type funcRule struct {
+	name  string
+	check func(a *Archive) []Finding
+}
+
+func (r funcRule) Name() string {
+	return r.name
+}
+
+func (r funcRule) Check(a *Archive) []Finding {
+	return r.check(a)
+}
+
+// unknownCommandRule reports stages and project builds using commands
+// which don't exist or belong to another project
+type unknownCommandRule struct{}
+
+func (unknownCommandRule) Name() string {
+	return "unknown-command"
+}
+
+func (r unknownCommandRule) Check(a *Archive) []Finding {
+	var findings []Finding
+	finding := func(resource, name, message string) {
+		findings = append(findings, Finding{Rule: r.Name(), Severity: Error, Resource: resource, Name: name, Message: message})
+	}
+
+	for _, project := range a.Projects {
+		if project.BuildCommandID == nil {
+			continue
+		}
+		if message := commandProblem(a, *project.BuildCommandID, id(project.ID)); message != "" {
+			finding("project", a.ProjectName(project), "build "+message)
+		}
+	}
+	for _, stage := range a.Stages {
+		for _, commandID := range stage.CommandIds {
+			if message := commandProblem(a, id(commandID), id(stage.ProjectID)); message != "" {
+				finding("stage", a.StageName(stage), message)
+			}
+		}
+	}
+
+	return findings
+}
+
+func commandProblem(a *Archive, commandID int, projectID int) string {
+	command := a.Command(commandID)
+	if command == nil {
+		return "command " + strconv.Itoa(commandID) + " does not exist"
+	}
+	if !command.IsGlobal() && *command.ProjectID != projectID {
+		return "command " + a.CommandName(command) + " belongs to another project"
+	}
+
+	return ""
+}
+
+func isTrue(b *bool) bool {
+	return b != nil && *b
+}
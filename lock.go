@@ -0,0 +1,177 @@
+package samson
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"time"
+)
+
+// LockService service
+type LockService service
+
+// Resource types a lock can be scoped to
+// Locks without a resource type are global
+const (
+	LockResourceEnvironment = "Environment"
+	LockResourceProject     = "Project"
+	LockResourceStage       = "Stage"
+)
+
+// Lock model
+type Lock struct {
+	ID           *int       `json:"id,omitempty"`
+	ResourceType *string    `json:"resource_type,omitempty"`
+	ResourceID   *int       `json:"resource_id,omitempty"`
+	Description  *string    `json:"description,omitempty"`
+	Warning      *bool      `json:"warning,omitempty"`
+	DeleteAt     *time.Time `json:"delete_at,omitempty"`
+	UserID       *int       `json:"user_id,omitempty"`
+	CreatedAt    *time.Time `json:"created_at,omitempty"`
+	UpdatedAt    *time.Time `json:"updated_at,omitempty"`
+}
+
+// IsGlobal returns whether the lock applies to all deploys
+func (l *Lock) IsGlobal() bool {
+	return l.ResourceType == nil || *l.ResourceType == ""
+}
+
+// IsWarning returns whether the lock only warns instead of blocking deploys
+func (l *Lock) IsWarning() bool {
+	return l.Warning != nil && *l.Warning
+}
+
+// IsExpired returns whether the lock has reached its automatic expiry time
+func (l *Lock) IsExpired(now time.Time) bool {
+	return l.DeleteAt != nil && !l.DeleteAt.After(now)
+}
+
+func (l *Lock) appliesTo(resourceType string, resourceID int) bool {
+	return l.ResourceType != nil && *l.ResourceType == resourceType &&
+		l.ResourceID != nil && *l.ResourceID == resourceID
+}
+
+// List returns all locks
+func (service *LockService) List() ([]*Lock, *Call, error) {
+	path := "/locks.json"
+	method := "GET"
+
+	call, err := service.s.NewCall(method, path, nil, nil, nil)
+	if err != nil {
+		return nil, call, err
+	}
+
+	type response struct {
+		Locks []*Lock `json:"locks,omitempty"`
+	}
+	var res response
+	err = call.Do(&res)
+	if err != nil {
+		return nil, call, err
+	}
+
+	return res.Locks, call, nil
+}
+
+// Create creates a new lock resource
+func (service *LockService) Create(lock *Lock) (*Lock, *Call, error) {
+	bytesArray, _ := json.Marshal(lock)
+
+	path := "/locks.json"
+	method := "POST"
+
+	call, err := service.s.NewCall(method, path, nil, nil, bytes.NewReader(bytesArray))
+	if err != nil {
+		return nil, call, err
+	}
+
+	err = call.Do(&lock)
+	if err != nil {
+		return nil, call, err
+	}
+
+	return lock, call, nil
+}
+
+// Delete deletes a sinlge lock resource
+func (service *LockService) Delete(id int) (*Call, error) {
+	path := fmt.Sprintf("/locks/%d.json", id)
+	method := "DELETE"
+
+	call, err := service.s.NewCall(method, path, nil, nil, nil)
+	if err != nil {
+		return call, err
+	}
+
+	return call, call.Do(nil)
+}
+
+// StageLocks returns the unexpired locks affecting a stage, including
+// global locks and the locks of its project and environments
+func (service *LockService) StageLocks(stageID int) ([]*Lock, *Call, error) {
+	stage, call, err := service.s.Stages.Get(stageID)
+	if err != nil {
+		return nil, call, err
+	}
+
+	locks, call, err := service.List()
+	if err != nil {
+		return nil, call, err
+	}
+
+	now := time.Now()
+	stageLocks := []*Lock{}
+	environmentLocks := []*Lock{}
+	for _, lock := range locks {
+		if lock.IsExpired(now) {
+			continue
+		}
+
+		switch {
+		case lock.IsGlobal(), lock.appliesTo(LockResourceStage, stageID):
+			stageLocks = append(stageLocks, lock)
+		case stage.ProjectID != nil && lock.appliesTo(LockResourceProject, *stage.ProjectID):
+			stageLocks = append(stageLocks, lock)
+		case lock.ResourceType != nil && *lock.ResourceType == LockResourceEnvironment:
+			environmentLocks = append(environmentLocks, lock)
+		}
+	}
+
+	if len(environmentLocks) == 0 {
+		return stageLocks, call, nil
+	}
+
+	// environments are only known through the deploy groups of the stage
+	deployGroups, call, err := service.s.Stages.DeployGroups(stageID)
+	if err != nil {
+		return nil, call, err
+	}
+
+	for _, lock := range environmentLocks {
+		for _, deployGroup := range deployGroups {
+			if deployGroup.EnvironmentID != nil && lock.appliesTo(LockResourceEnvironment, *deployGroup.EnvironmentID) {
+				stageLocks = append(stageLocks, lock)
+				break
+			}
+		}
+	}
+
+	return stageLocks, call, nil
+}
+
+// IsStageLocked returns the lock blocking deploys of a stage
+// The returned lock is nil when the stage is not locked, warnings are ignored
+func (service *LockService) IsStageLocked(stageID int) (*Lock, *Call, error) {
+	locks, call, err := service.StageLocks(stageID)
+	if err != nil {
+		return nil, call, err
+	}
+
+	for _, lock := range locks {
+		if !lock.IsWarning() {
+			return lock, call, nil
+		}
+	}
+
+	return nil, call, nil
+}
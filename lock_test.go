@@ -0,0 +1,244 @@
+package samson
+
+import (
+	"encoding/json"
+	"fmt"
+	"log"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func ExampleLockService_Create() {
+	client := New("token")
+
+	deleteAt := time.Now().Add(2 * time.Hour)
+	lock := &Lock{
+		ResourceType: String(LockResourceStage),
+		ResourceID:   Int(3),
+		Description:  String("Broken migration"),
+		DeleteAt:     &deleteAt,
+	}
+
+	lock, _, err := client.Locks.Create(lock)
+	if err != nil {
+		log.Fatal(err)
+	}
+}
+
+func ExampleLockService_IsStageLocked() {
+	client := New("token")
+
+	lock, _, err := client.Locks.IsStageLocked(3)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	if lock != nil {
+		fmt.Println("locked by user", *lock.UserID, *lock.Description)
+	}
+}
+
+func lockTestHandler(assert *assert.Assertions, stageDeployGroups bool) http.HandlerFunc {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal("GET", r.Method)
+		checkHeaders(r, assert)
+
+		switch r.URL.Path {
+		case "/locks.json":
+			fmt.Fprintln(w, readTestData("locks.json"))
+		case "/stages/1.json":
+			fmt.Fprintln(w, readTestData("stage.json"))
+		case "/stages/1/deploy_groups.json":
+			assert.True(stageDeployGroups)
+			fmt.Fprintln(w, readTestData("deploy_groups.json"))
+		default:
+			w.WriteHeader(404)
+			fmt.Fprintln(w, readTestData("error-notfound.json"))
+		}
+	})
+}
+
+func TestLockIsExpired(t *testing.T) {
+	assert := assert.New(t)
+
+	now := time.Now()
+	past := now.Add(-time.Minute)
+	future := now.Add(time.Minute)
+
+	assert.False((&Lock{}).IsExpired(now))
+	assert.True((&Lock{DeleteAt: &past}).IsExpired(now))
+	assert.False((&Lock{DeleteAt: &future}).IsExpired(now))
+}
+
+func TestLockIsGlobal(t *testing.T) {
+	assert := assert.New(t)
+
+	assert.True((&Lock{}).IsGlobal())
+	assert.True((&Lock{ResourceType: String("")}).IsGlobal())
+	assert.False((&Lock{ResourceType: String(LockResourceStage)}).IsGlobal())
+}
+
+func TestLockServiceList(t *testing.T) {
+	var err error
+	assert := assert.New(t)
+
+	server := httptest.NewServer(lockTestHandler(assert, false))
+	defer server.Close()
+
+	client = New(token)
+	client.BaseURL = server.URL
+
+	locks, call, err := client.Locks.List()
+	assert.Nil(err)
+	assert.IsType(&Call{}, call)
+	assert.Equal(4, len(locks))
+	assert.True(locks[0].IsGlobal())
+	assert.True(locks[0].IsWarning())
+}
+
+func TestLockServiceList_fail(t *testing.T) {
+	var err error
+	assert := assert.New(t)
+
+	client = New(token)
+	client.BaseURL = "^http://localhost"
+
+	locks, call, err := client.Locks.List()
+	assert.NotNil(err)
+	assert.IsType(&Call{}, call)
+	assert.Nil(locks)
+}
+
+func TestLockServiceCreate(t *testing.T) {
+	var err error
+	assert := assert.New(t)
+
+	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal("POST", r.Method)
+		assert.Equal("/locks.json", r.URL.Path)
+		checkHeaders(r, assert)
+
+		var payload map[string]interface{}
+		err := json.NewDecoder(r.Body).Decode(&payload)
+		assert.Nil(err)
+		assert.Equal("Stage", payload["resource_type"])
+		assert.Equal(float64(3), payload["resource_id"])
+		assert.Equal("Broken migration", payload["description"])
+		assert.Equal("2018-03-29T10:24:56.393Z", payload["delete_at"])
+
+		w.WriteHeader(200)
+		fmt.Fprintln(w, readTestData("lock.json"))
+	})
+
+	server := httptest.NewServer(handler)
+	defer server.Close()
+
+	client = New(token)
+	client.BaseURL = server.URL
+
+	deleteAt := time.Date(2018, 3, 29, 10, 24, 56, 393000000, time.UTC)
+	lock := &Lock{
+		ResourceType: String(LockResourceStage),
+		ResourceID:   Int(3),
+		Description:  String("Broken migration"),
+		DeleteAt:     &deleteAt,
+	}
+
+	lock, call, err := client.Locks.Create(lock)
+	assert.Nil(err)
+	assert.IsType(&Call{}, call)
+	assert.Equal(3, *lock.ID)
+}
+
+func TestLockServiceCreate_fail(t *testing.T) {
+	var err error
+	assert := assert.New(t)
+
+	client = New(token)
+	client.BaseURL = "^http://localhost"
+
+	lock, call, err := client.Locks.Create(&Lock{})
+	assert.NotNil(err)
+	assert.IsType(&Call{}, call)
+	assert.Nil(lock)
+}
+
+func TestLockServiceDelete(t *testing.T) {
+	var err error
+	assert := assert.New(t)
+
+	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal("DELETE", r.Method)
+		assert.Equal("/locks/3.json", r.URL.Path)
+		checkHeaders(r, assert)
+
+		w.WriteHeader(200)
+		fmt.Fprintln(w, "")
+	})
+
+	server := httptest.NewServer(handler)
+	defer server.Close()
+
+	client = New(token)
+	client.BaseURL = server.URL
+
+	call, err := client.Locks.Delete(3)
+	assert.Nil(err)
+	assert.IsType(&Call{}, call)
+}
+
+func TestLockServiceStageLocks(t *testing.T) {
+	var err error
+	assert := assert.New(t)
+
+	server := httptest.NewServer(lockTestHandler(assert, true))
+	defer server.Close()
+
+	client = New(token)
+	client.BaseURL = server.URL
+
+	// the project lock of the fixture is expired
+	locks, call, err := client.Locks.StageLocks(1)
+	assert.Nil(err)
+	assert.IsType(&Call{}, call)
+	assert.Equal(2, len(locks))
+	assert.Equal(1, *locks[0].ID)
+	assert.Equal(2, *locks[1].ID)
+}
+
+func TestLockServiceStageLocks_fail(t *testing.T) {
+	var err error
+	assert := assert.New(t)
+
+	server := httptest.NewServer(lockTestHandler(assert, false))
+	defer server.Close()
+
+	client = New(token)
+	client.BaseURL = server.URL
+
+	locks, call, err := client.Locks.StageLocks(2)
+	assert.NotNil(err)
+	assert.IsType(&Call{}, call)
+	assert.Nil(locks)
+}
+
+func TestLockServiceIsStageLocked(t *testing.T) {
+	var err error
+	assert := assert.New(t)
+
+	server := httptest.NewServer(lockTestHandler(assert, true))
+	defer server.Close()
+
+	client = New(token)
+	client.BaseURL = server.URL
+
+	lock, call, err := client.Locks.IsStageLocked(1)
+	assert.Nil(err)
+	assert.IsType(&Call{}, call)
+	assert.Equal(2, *lock.ID)
+	assert.Equal(2, *lock.UserID)
+}
@@ -0,0 +1,324 @@
+// Package migrate copies a project with its stages, project commands and
+// environment variables from one samson instance to another.
+//
+// Global commands, environments and deploy groups referenced by the
+// project are not copied, they're resolved in the target by command
+// text, environment name and deploy group permalink. Anything that can't
+// be resolved is reported as a conflict before the target is changed.
+package migrate
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	samson "github.com/tolgaakyuz/samson-go"
+	"github.com/tolgaakyuz/samson-go/backup"
+)
+
+// Conflict is a reason the project can't be copied
+type Conflict struct {
+	Resource string
+	Name     string
+	Reason   string
+}
+
+func (c Conflict) String() string {
+	return fmt.Sprintf("%s %s: %s", c.Resource, c.Name, c.Reason)
+}
+
+// ConflictError is returned when the copy would conflict with the target
+type ConflictError struct {
+	Conflicts []Conflict
+}
+
+func (e *ConflictError) Error() string {
+	conflicts := make([]string, len(e.Conflicts))
+	for i, conflict := range e.Conflicts {
+		conflicts[i] = conflict.String()
+	}
+
+	return "migrate: " + strings.Join(conflicts, "; ")
+}
+
+// Options configures the copy
+type Options struct {
+	// Permalink renames the project in the target, defaults to its
+	// permalink in the source
+	Permalink string
+}
+
+// Result is the copied project and the ids of its resources in the target
+type Result struct {
+	Project *samson.Project
+	IDs     *backup.IDMap
+}
+
+// CopyProject copies the project with the given permalink from source to
+// target, the target is left untouched when there are conflicts
+func CopyProject(source, target *samson.Samson, permalink string, opts Options) (*Result, error) {
+	src, err := load(source, permalink)
+	if err != nil {
+		return nil, err
+	}
+
+	targetPermalink := opts.Permalink
+	if targetPermalink == "" {
+		targetPermalink = permalink
+	}
+
+	ids, conflicts, err := resolve(src, target, targetPermalink)
+	if err != nil {
+		return nil, err
+	}
+	if len(conflicts) > 0 {
+		return nil, &ConflictError{Conflicts: conflicts}
+	}
+
+	project := *src.project
+	project.Permalink = samson.String(targetPermalink)
+	archive := &backup.Archive{
+		Version:  backup.Version,
+		Projects: []*samson.Project{&project},
+		Commands: src.projectCommands,
+		Stages:   src.stages,
+	}
+
+	err = backup.Restore(target, archive, ids)
+	if err != nil {
+		return nil, err
+	}
+
+	copied, _, err := target.Projects.Get(ids.Projects[*src.project.ID])
+	if err != nil {
+		return nil, err
+	}
+
+	return &Result{Project: copied, IDs: ids}, nil
+}
+
+// sourceProject is the project to copy and what it references
+type sourceProject struct {
+	project         *samson.Project
+	stages          []*samson.Stage
+	projectCommands []*samson.Command
+	globalCommands  map[int]*samson.Command
+	environments    map[int]*samson.Environment
+	deployGroups    map[int]*samson.DeployGroup
+}
+
+func load(client *samson.Samson, permalink string) (*sourceProject, error) {
+	projects, _, err := client.Projects.List()
+	if err != nil {
+		return nil, err
+	}
+
+	src := &sourceProject{
+		globalCommands: map[int]*samson.Command{},
+		environments:   map[int]*samson.Environment{},
+		deployGroups:   map[int]*samson.DeployGroup{},
+	}
+	for _, project := range projects {
+		if project.Permalink != nil && *project.Permalink == permalink {
+			src.project, _, err = client.Projects.Get(*project.ID)
+			if err != nil {
+				return nil, err
+			}
+			break
+		}
+	}
+	if src.project == nil {
+		return nil, fmt.Errorf("migrate: project %s not found in source", permalink)
+	}
+
+	stages, _, err := client.Stages.List()
+	if err != nil {
+		return nil, err
+	}
+	for _, stage := range stages {
+		if stage.ProjectID != nil && *stage.ProjectID == *src.project.ID {
+			src.stages = append(src.stages, stage)
+		}
+	}
+
+	commands, _, err := client.Commands.List()
+	if err != nil {
+		return nil, err
+	}
+	for _, command := range commands {
+		if command.IsGlobal() {
+			src.globalCommands[*command.ID] = command
+		} else if *command.ProjectID == *src.project.ID {
+			src.projectCommands = append(src.projectCommands, command)
+		}
+	}
+
+	environments, _, err := client.Environments.List()
+	if err != nil {
+		return nil, err
+	}
+	for _, environment := range environments {
+		src.environments[*environment.ID] = environment
+	}
+
+	deployGroups, _, err := client.DeployGroups.List()
+	if err != nil {
+		return nil, err
+	}
+	for _, deployGroup := range deployGroups {
+		src.deployGroups[*deployGroup.ID] = deployGroup
+	}
+
+	return src, nil
+}
+
+// resolve maps the resources the project references onto the target and
+// collects what can't be mapped
+func resolve(src *sourceProject, target *samson.Samson, permalink string) (*backup.IDMap, []Conflict, error) {
+	var conflicts []Conflict
+	ids := backup.NewIDMap()
+
+	projects, _, err := target.Projects.List()
+	if err != nil {
+		return nil, nil, err
+	}
+	for _, project := range projects {
+		if project.Permalink != nil && *project.Permalink == permalink {
+			conflicts = append(conflicts, Conflict{"project", permalink, "permalink already exists in target"})
+		}
+	}
+
+	commands, _, err := target.Commands.List()
+	if err != nil {
+		return nil, nil, err
+	}
+	globals := map[string]int{}
+	for _, command := range commands {
+		if command.IsGlobal() {
+			globals[str(command.Command)] = *command.ID
+		}
+	}
+
+	environments, _, err := target.Environments.List()
+	if err != nil {
+		return nil, nil, err
+	}
+	environmentsByName := map[string]int{}
+	for _, environment := range environments {
+		environmentsByName[str(environment.Name)] = *environment.ID
+	}
+
+	deployGroups, _, err := target.DeployGroups.List()
+	if err != nil {
+		return nil, nil, err
+	}
+	deployGroupsByPermalink := map[string]int{}
+	for _, deployGroup := range deployGroups {
+		deployGroupsByPermalink[str(deployGroup.Permalink)] = *deployGroup.ID
+	}
+
+	projectCommands := map[int]bool{}
+	for _, command := range src.projectCommands {
+		projectCommands[*command.ID] = true
+	}
+	resolveCommand := func(resource, name string, id int) {
+		if projectCommands[id] {
+			return
+		}
+		command, ok := src.globalCommands[id]
+		if !ok {
+			conflicts = append(conflicts, Conflict{resource, name, fmt.Sprintf("command %d not found in source", id)})
+			return
+		}
+		targetID, ok := globals[str(command.Command)]
+		if !ok {
+			conflicts = append(conflicts, Conflict{resource, name, fmt.Sprintf("global command %q not found in target", str(command.Command))})
+			return
+		}
+		ids.Commands[id] = targetID
+	}
+	resolveDeployGroup := func(resource, name string, id int) {
+		deployGroup, ok := src.deployGroups[id]
+		if !ok {
+			conflicts = append(conflicts, Conflict{resource, name, fmt.Sprintf("deploy group %d not found in source", id)})
+			return
+		}
+		targetID, ok := deployGroupsByPermalink[str(deployGroup.Permalink)]
+		if !ok {
+			conflicts = append(conflicts, Conflict{resource, name, fmt.Sprintf("deploy group %s not found in target", str(deployGroup.Permalink))})
+			return
+		}
+		ids.DeployGroups[id] = targetID
+	}
+	resolveEnvironment := func(resource, name string, id int) {
+		environment, ok := src.environments[id]
+		if !ok {
+			conflicts = append(conflicts, Conflict{resource, name, fmt.Sprintf("environment %d not found in source", id)})
+			return
+		}
+		targetID, ok := environmentsByName[str(environment.Name)]
+		if !ok {
+			conflicts = append(conflicts, Conflict{resource, name, fmt.Sprintf("environment %s not found in target", str(environment.Name))})
+			return
+		}
+		ids.Environments[id] = targetID
+	}
+
+	if src.project.BuildCommandID != nil {
+		resolveCommand("project", permalink, *src.project.BuildCommandID)
+	}
+
+	for _, variable := range src.project.EnvironmentVariableAttributes {
+		name := "environment variable " + str(variable.Name)
+		parts := strings.SplitN(str(variable.ScopeTypeAndID), "-", 2)
+		if len(parts) != 2 {
+			continue
+		}
+		id, err := strconv.Atoi(parts[1])
+		if err != nil {
+			continue
+		}
+		switch parts[0] {
+		case "Environment":
+			resolveEnvironment("project", name, id)
+		case "DeployGroup":
+			resolveDeployGroup("project", name, id)
+		}
+	}
+
+	stages := map[int]bool{}
+	for _, stage := range src.stages {
+		stages[*stage.ID] = true
+	}
+	for _, stage := range src.stages {
+		name := permalink + "/" + str(stage.Permalink)
+		for _, id := range stage.CommandIds {
+			if id != nil {
+				resolveCommand("stage", name, *id)
+			}
+		}
+		for _, id := range stage.DeployGroupIds {
+			if id != nil {
+				resolveDeployGroup("stage", name, *id)
+			}
+		}
+		for _, id := range stage.NextStageIds {
+			if id != nil && !stages[*id] {
+				conflicts = append(conflicts, Conflict{"stage", name, fmt.Sprintf("next stage %d belongs to another project", *id)})
+			}
+		}
+		if stage.TemplateStageID != nil && !stages[*stage.TemplateStageID] {
+			conflicts = append(conflicts, Conflict{"stage", name, fmt.Sprintf("template stage %d belongs to another project", *stage.TemplateStageID)})
+		}
+	}
+
+	return ids, conflicts, nil
+}
+
+func str(s *string) string {
+	if s == nil {
+		return ""
+	}
+
+	return *s
+}
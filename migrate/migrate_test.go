@@ -0,0 +1,192 @@
+package migrate
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	samson "github.com/tolgaakyuz/samson-go"
+	"github.com/tolgaakyuz/samson-go/internal/samsontest"
+)
+
+func seedSource(f *samsontest.Server) {
+	f.Add("environments", `{"id": 1, "name": "Production", "production": "1"}`)
+	f.Add("deploy_groups", `{"id": 2, "name": "Pod 1", "permalink": "pod1", "environment_id": 1}`)
+	f.Add("projects", `{"id": 3, "name": "Example", "permalink": "example", "created_at": "2018-03-26T11:52:01Z",
+		"environment_variables_attributes": [
+			{"id": 1, "name": "RAILS_ENV", "value": "production", "scope_type_and_id": "Environment-1"},
+			{"id": 2, "name": "POD", "value": "1", "scope_type_and_id": "DeployGroup-2"},
+			{"id": 3, "name": "GLOBAL", "value": "yes", "scope_type_and_id": ""}
+		]}`)
+	f.Add("projects", `{"id": 4, "name": "Other", "permalink": "other", "created_at": "2018-03-26T11:52:01Z"}`)
+	f.Add("commands", `{"id": 5, "command": "./notify.sh"}`)
+	f.Add("commands", `{"id": 6, "command": "bundle exec cap deploy", "project_id": "3"}`)
+	f.Add("commands", `{"id": 7, "command": "echo other", "project_id": "4"}`)
+	f.Add("stages", `{"id": 8, "name": "Staging", "permalink": "staging", "project_id": 3,
+		"command_ids": ["6"], "next_stage_ids": [9], "created_at": "2018-03-26T11:52:01Z"}`)
+	f.Add("stages", `{"id": 9, "name": "Production", "permalink": "production", "project_id": 3,
+		"command_ids": ["6", "5"], "deploy_group_ids": [2], "created_at": "2018-03-26T11:52:01Z"}`)
+	f.Add("stages", `{"id": 10, "name": "Other", "permalink": "other", "project_id": 4, "created_at": "2018-03-26T11:52:01Z"}`)
+}
+
+func seedTarget(f *samsontest.Server) {
+	f.Add("environments", `{"id": 11, "name": "Production", "production": "1"}`)
+	f.Add("deploy_groups", `{"id": 12, "name": "Pod 1", "permalink": "pod1", "environment_id": 11}`)
+	f.Add("commands", `{"id": 13, "command": "./notify.sh"}`)
+}
+
+func client(f *samsontest.Server) *samson.Samson {
+	server := f.Start()
+
+	client := samson.New("token")
+	client.BaseURL = server.URL
+
+	return client
+}
+
+func TestCopyProject(t *testing.T) {
+	assert := assert.New(t)
+
+	source := samsontest.New()
+	seedSource(source)
+	target := samsontest.New()
+	seedTarget(target)
+
+	result, err := CopyProject(client(source), client(target), "example", Options{})
+	assert.Nil(err)
+	assert.Equal(101, *result.Project.ID)
+	assert.Equal("example", *result.Project.Permalink)
+	assert.Equal(map[int]int{3: 101}, result.IDs.Projects)
+	assert.Equal(map[int]int{5: 13, 6: 102}, result.IDs.Commands)
+	assert.Equal(map[int]int{8: 103, 9: 104}, result.IDs.Stages)
+
+	assert.Len(target.Resources["projects"], 1)
+	assert.Len(target.Resources["commands"], 2)
+	assert.Equal(float64(101), target.Resources["commands"][102]["project_id"])
+
+	variables := target.Resources["projects"][101]["environment_variables_attributes"].([]interface{})
+	scopes := []interface{}{}
+	for _, variable := range variables {
+		scopes = append(scopes, variable.(map[string]interface{})["scope_type_and_id"])
+	}
+	assert.Equal([]interface{}{"Environment-11", "DeployGroup-12", ""}, scopes)
+
+	staging := target.Resources["stages"][103]
+	assert.Equal(float64(101), staging["project_id"])
+	assert.Equal([]interface{}{float64(102)}, staging["command_ids"])
+	assert.Equal([]interface{}{float64(104)}, staging["next_stage_ids"])
+
+	production := target.Resources["stages"][104]
+	assert.Equal([]interface{}{float64(102), float64(13)}, production["command_ids"])
+	assert.Equal([]interface{}{float64(12)}, production["deploy_group_ids"])
+}
+
+func TestCopyProject_permalink(t *testing.T) {
+	assert := assert.New(t)
+
+	source := samsontest.New()
+	seedSource(source)
+	target := samsontest.New()
+	seedTarget(target)
+	target.Add("projects", `{"id": 20, "name": "Example", "permalink": "example"}`)
+
+	_, err := CopyProject(client(source), client(target), "example", Options{})
+	assert.EqualError(err, "migrate: project example: permalink already exists in target")
+
+	result, err := CopyProject(client(source), client(target), "example", Options{Permalink: "example-copy"})
+	assert.Nil(err)
+	assert.Equal("example-copy", *result.Project.Permalink)
+}
+
+func TestCopyProject_conflicts(t *testing.T) {
+	assert := assert.New(t)
+
+	source := samsontest.New()
+	seedSource(source)
+	source.Add("stages", `{"id": 30, "name": "Canary", "permalink": "canary", "project_id": 3,
+		"command_ids": ["7", "99"], "deploy_group_ids": [98], "next_stage_ids": [10], "template_stage_id": 10}`)
+	target := samsontest.New()
+
+	_, err := CopyProject(client(source), client(target), "example", Options{})
+	assert.NotNil(err)
+	assert.Equal([]Conflict{
+		{"project", "environment variable RAILS_ENV", "environment Production not found in target"},
+		{"project", "environment variable POD", "deploy group pod1 not found in target"},
+		{"stage", "example/production", `global command "./notify.sh" not found in target`},
+		{"stage", "example/production", "deploy group pod1 not found in target"},
+		{"stage", "example/canary", "command 7 not found in source"},
+		{"stage", "example/canary", "command 99 not found in source"},
+		{"stage", "example/canary", "deploy group 98 not found in source"},
+		{"stage", "example/canary", "next stage 10 belongs to another project"},
+		{"stage", "example/canary", "template stage 10 belongs to another project"},
+	}, err.(*ConflictError).Conflicts)
+	assert.Contains(err.Error(), "migrate: project environment variable RAILS_ENV: environment Production not found in target; ")
+	assert.Empty(target.Resources["projects"])
+	for _, request := range target.Requests {
+		assert.True(request[:4] == "GET ", request)
+	}
+}
+
+func TestCopyProject_fail(t *testing.T) {
+	assert := assert.New(t)
+
+	source := samsontest.New()
+	seedSource(source)
+	_, err := CopyProject(client(source), client(samsontest.New()), "missing", Options{})
+	assert.EqualError(err, "migrate: project missing not found in source")
+
+	for _, path := range []string{
+		"GET /projects.json",
+		"GET /projects/3.json",
+		"GET /stages.json",
+		"GET /commands.json",
+		"GET /environments.json",
+		"GET /deploy_groups.json",
+	} {
+		source := samsontest.New()
+		seedSource(source)
+		source.Fail = path
+		target := samsontest.New()
+		seedTarget(target)
+
+		_, err := CopyProject(client(source), client(target), "example", Options{})
+		assert.EqualError(err, "invalid", "source "+path)
+	}
+
+	for _, path := range []string{
+		"GET /projects.json",
+		"GET /commands.json",
+		"GET /environments.json",
+		"GET /deploy_groups.json",
+		"GET /projects/101.json",
+	} {
+		source := samsontest.New()
+		seedSource(source)
+		target := samsontest.New()
+		seedTarget(target)
+		target.Fail = path
+
+		_, err := CopyProject(client(source), client(target), "example", Options{})
+		assert.EqualError(err, "invalid", "target "+path)
+	}
+
+	target := samsontest.New()
+	seedTarget(target)
+	target.Fail = "POST /stages.json"
+	_, err = CopyProject(client(source), client(target), "example", Options{})
+	assert.EqualError(err, "stage staging: invalid")
+}
+
+func ExampleCopyProject() {
+	internal := samson.New("internal-token")
+	internal.BaseURL = "https://samson.internal.example.com"
+	customer := samson.New("customer-token")
+	customer.BaseURL = "https://samson.customer.example.com"
+
+	_, err := CopyProject(internal, customer, "example", Options{})
+	if conflicts, ok := err.(*ConflictError); ok {
+		for _, conflict := range conflicts.Conflicts {
+			fmt.Println(conflict)
+		}
+	}
+}
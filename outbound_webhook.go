@@ -0,0 +1,157 @@
+package samson
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"time"
+)
+
+// OutboundWebhookService service
+type OutboundWebhookService service
+
+// Authentication types of outbound webhooks
+const (
+	OutboundWebhookAuthNone   = "None"
+	OutboundWebhookAuthBasic  = "Basic"
+	OutboundWebhookAuthToken  = "Token"
+	OutboundWebhookAuthBearer = "Bearer"
+)
+
+// OutboundWebhook model for the webhooks Samson calls before or after deploys
+// Password is never returned by the api
+type OutboundWebhook struct {
+	ID            *int       `json:"id,omitempty"`
+	URL           *string    `json:"url,omitempty"`
+	AuthType      *string    `json:"auth_type,omitempty"`
+	Username      *string    `json:"username,omitempty"`
+	Password      *string    `json:"password,omitempty"`
+	Insecure      *bool      `json:"insecure,omitempty"`
+	Global        *bool      `json:"global,omitempty"`
+	BeforeDeploy  *bool      `json:"before_deploy,omitempty"`
+	AfterDeploy   *bool      `json:"after_deploy,omitempty"`
+	OnlyOnFailure *bool      `json:"only_on_failure,omitempty"`
+	StageIds      []*int     `json:"stage_ids,omitempty"`
+	CreatedAt     *time.Time `json:"created_at,omitempty"`
+	UpdatedAt     *time.Time `json:"updated_at,omitempty"`
+}
+
+// List returns all outbound webhooks
+func (service *OutboundWebhookService) List() ([]*OutboundWebhook, *Call, error) {
+	path := "/outbound_webhooks.json"
+	method := "GET"
+
+	return service.list(method, path)
+}
+
+// ListForStage returns the outbound webhooks attached to a stage
+func (service *OutboundWebhookService) ListForStage(stageID int) ([]*OutboundWebhook, *Call, error) {
+	path := fmt.Sprintf("/stages/%d/outbound_webhooks.json", stageID)
+	method := "GET"
+
+	return service.list(method, path)
+}
+
+func (service *OutboundWebhookService) list(method, path string) ([]*OutboundWebhook, *Call, error) {
+	call, err := service.s.NewCall(method, path, nil, nil, nil)
+	if err != nil {
+		return nil, call, err
+	}
+
+	type response struct {
+		OutboundWebhooks []*OutboundWebhook `json:"outbound_webhooks,omitempty"`
+	}
+	var res response
+	err = call.Do(&res)
+	if err != nil {
+		return nil, call, err
+	}
+
+	return res.OutboundWebhooks, call, nil
+}
+
+// Get returns a single outbound webhook resource
+func (service *OutboundWebhookService) Get(id int) (*OutboundWebhook, *Call, error) {
+	path := fmt.Sprintf("/outbound_webhooks/%d.json", id)
+	method := "GET"
+
+	call, err := service.s.NewCall(method, path, nil, nil, nil)
+	if err != nil {
+		return nil, call, err
+	}
+
+	var webhook OutboundWebhook
+	err = call.Do(&webhook)
+	if err != nil {
+		return nil, call, err
+	}
+
+	return &webhook, call, nil
+}
+
+// Upsert updates or creates a new outbound webhook resource
+func (service *OutboundWebhookService) Upsert(webhook *OutboundWebhook) (*OutboundWebhook, *Call, error) {
+	bytesArray, _ := json.Marshal(webhook)
+
+	var path string
+	var method string
+
+	if webhook.ID != nil {
+		path = fmt.Sprintf("/outbound_webhooks/%d.json", *webhook.ID)
+		method = "PUT"
+	} else {
+		path = "/outbound_webhooks.json"
+		method = "POST"
+	}
+
+	call, err := service.s.NewCall(method, path, nil, nil, bytes.NewReader(bytesArray))
+	if err != nil {
+		return nil, call, err
+	}
+
+	err = call.Do(&webhook)
+	if err != nil {
+		return nil, call, err
+	}
+
+	return webhook, call, nil
+}
+
+// Delete deletes a sinlge outbound webhook resource
+func (service *OutboundWebhookService) Delete(id int) (*Call, error) {
+	path := fmt.Sprintf("/outbound_webhooks/%d.json", id)
+	method := "DELETE"
+
+	call, err := service.s.NewCall(method, path, nil, nil, nil)
+	if err != nil {
+		return call, err
+	}
+
+	return call, call.Do(nil)
+}
+
+// Attach makes a stage call the outbound webhook on its deploys
+func (service *OutboundWebhookService) Attach(id int, stageID int) (*Call, error) {
+	path := fmt.Sprintf("/stages/%d/outbound_webhooks/%d.json", stageID, id)
+	method := "PUT"
+
+	call, err := service.s.NewCall(method, path, nil, nil, nil)
+	if err != nil {
+		return call, err
+	}
+
+	return call, call.Do(nil)
+}
+
+// Detach stops a stage from calling the outbound webhook
+func (service *OutboundWebhookService) Detach(id int, stageID int) (*Call, error) {
+	path := fmt.Sprintf("/stages/%d/outbound_webhooks/%d.json", stageID, id)
+	method := "DELETE"
+
+	call, err := service.s.NewCall(method, path, nil, nil, nil)
+	if err != nil {
+		return call, err
+	}
+
+	return call, call.Do(nil)
+}
@@ -0,0 +1,320 @@
+package samson
+
+import (
+	"encoding/json"
+	"fmt"
+	"log"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func ExampleOutboundWebhookService_Upsert() {
+	client := New("token")
+
+	webhook := &OutboundWebhook{
+		URL:          String("https://ci.example.com/samson/before"),
+		AuthType:     String(OutboundWebhookAuthBearer),
+		Password:     String("secret token"),
+		BeforeDeploy: Bool(true),
+	}
+
+	webhook, _, err := client.OutboundWebhooks.Upsert(webhook)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	_, err = client.OutboundWebhooks.Attach(*webhook.ID, 3)
+	if err != nil {
+		log.Fatal(err)
+	}
+}
+
+func TestOutboundWebhookServiceList(t *testing.T) {
+	var err error
+	assert := assert.New(t)
+
+	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal("GET", r.Method)
+		assert.Equal("/outbound_webhooks.json", r.URL.Path)
+		checkHeaders(r, assert)
+
+		w.WriteHeader(200)
+		fmt.Fprintln(w, readTestData("outbound_webhooks.json"))
+	})
+
+	server := httptest.NewServer(handler)
+	defer server.Close()
+
+	client = New(token)
+	client.BaseURL = server.URL
+
+	webhooks, call, err := client.OutboundWebhooks.List()
+	assert.Nil(err)
+	assert.IsType(&Call{}, call)
+	assert.Equal(2, len(webhooks))
+	assert.Equal(OutboundWebhookAuthBasic, *webhooks[1].AuthType)
+	assert.True(*webhooks[1].OnlyOnFailure)
+}
+
+func TestOutboundWebhookServiceList_fail(t *testing.T) {
+	var err error
+	assert := assert.New(t)
+
+	client = New(token)
+	client.BaseURL = "^http://localhost"
+
+	webhooks, call, err := client.OutboundWebhooks.List()
+	assert.NotNil(err)
+	assert.IsType(&Call{}, call)
+	assert.Nil(webhooks)
+}
+
+func TestOutboundWebhookServiceListForStage(t *testing.T) {
+	var err error
+	assert := assert.New(t)
+
+	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal("GET", r.Method)
+		assert.Equal("/stages/1/outbound_webhooks.json", r.URL.Path)
+		checkHeaders(r, assert)
+
+		w.WriteHeader(200)
+		fmt.Fprintln(w, readTestData("outbound_webhooks.json"))
+	})
+
+	server := httptest.NewServer(handler)
+	defer server.Close()
+
+	client = New(token)
+	client.BaseURL = server.URL
+
+	webhooks, call, err := client.OutboundWebhooks.ListForStage(1)
+	assert.Nil(err)
+	assert.IsType(&Call{}, call)
+	assert.Equal(2, len(webhooks))
+}
+
+func TestOutboundWebhookServiceListForStage_fail(t *testing.T) {
+	var err error
+	assert := assert.New(t)
+
+	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(200)
+		fmt.Fprintln(w, "malformed json response")
+	})
+
+	server := httptest.NewServer(handler)
+	defer server.Close()
+
+	client = New(token)
+	client.BaseURL = server.URL
+
+	webhooks, call, err := client.OutboundWebhooks.ListForStage(1)
+	assert.NotNil(err)
+	assert.IsType(&Call{}, call)
+	assert.Nil(webhooks)
+}
+
+func TestOutboundWebhookServiceGet(t *testing.T) {
+	var err error
+	assert := assert.New(t)
+
+	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal("GET", r.Method)
+		assert.Equal("/outbound_webhooks/1.json", r.URL.Path)
+		checkHeaders(r, assert)
+
+		w.WriteHeader(200)
+		fmt.Fprintln(w, readTestData("outbound_webhook.json"))
+	})
+
+	server := httptest.NewServer(handler)
+	defer server.Close()
+
+	client = New(token)
+	client.BaseURL = server.URL
+
+	webhook, call, err := client.OutboundWebhooks.Get(1)
+	assert.Nil(err)
+	assert.IsType(&Call{}, call)
+	assert.Equal("https://ci.example.com/samson/before", *webhook.URL)
+	assert.Equal(2, len(webhook.StageIds))
+	assert.Nil(webhook.Password)
+}
+
+func TestOutboundWebhookServiceGet_fail(t *testing.T) {
+	var err error
+	assert := assert.New(t)
+
+	client = New(token)
+	client.BaseURL = "^http://localhost"
+
+	webhook, call, err := client.OutboundWebhooks.Get(1)
+	assert.NotNil(err)
+	assert.IsType(&Call{}, call)
+	assert.Nil(webhook)
+}
+
+func TestOutboundWebhookServiceCreate(t *testing.T) {
+	var err error
+	assert := assert.New(t)
+
+	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal("POST", r.Method)
+		assert.Equal("/outbound_webhooks.json", r.URL.Path)
+		checkHeaders(r, assert)
+
+		var payload map[string]interface{}
+		err := json.NewDecoder(r.Body).Decode(&payload)
+		assert.Nil(err)
+		assert.Equal("https://ci.example.com/samson/before", payload["url"])
+		assert.Equal("Bearer", payload["auth_type"])
+		assert.Equal("secret token", payload["password"])
+		assert.Equal(true, payload["before_deploy"])
+
+		w.WriteHeader(200)
+		fmt.Fprintln(w, readTestData("outbound_webhook.json"))
+	})
+
+	server := httptest.NewServer(handler)
+	defer server.Close()
+
+	client = New(token)
+	client.BaseURL = server.URL
+
+	webhook := &OutboundWebhook{
+		URL:          String("https://ci.example.com/samson/before"),
+		AuthType:     String(OutboundWebhookAuthBearer),
+		Password:     String("secret token"),
+		BeforeDeploy: Bool(true),
+	}
+
+	webhook, call, err := client.OutboundWebhooks.Upsert(webhook)
+	assert.Nil(err)
+	assert.IsType(&Call{}, call)
+	assert.Equal(1, *webhook.ID)
+}
+
+func TestOutboundWebhookServiceUpdate(t *testing.T) {
+	var err error
+	assert := assert.New(t)
+
+	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal("PUT", r.Method)
+		assert.Equal("/outbound_webhooks/1.json", r.URL.Path)
+		checkHeaders(r, assert)
+
+		var payload map[string]interface{}
+		err := json.NewDecoder(r.Body).Decode(&payload)
+		assert.Nil(err)
+		assert.Equal(true, payload["insecure"])
+
+		w.WriteHeader(200)
+		fmt.Fprintln(w, readTestData("outbound_webhook.json"))
+	})
+
+	server := httptest.NewServer(handler)
+	defer server.Close()
+
+	client = New(token)
+	client.BaseURL = server.URL
+
+	webhook := &OutboundWebhook{
+		ID:       Int(1),
+		Insecure: Bool(true),
+	}
+
+	webhook, call, err := client.OutboundWebhooks.Upsert(webhook)
+	assert.Nil(err)
+	assert.IsType(&Call{}, call)
+	assert.NotNil(webhook.CreatedAt)
+}
+
+func TestOutboundWebhookServiceUpsert_fail(t *testing.T) {
+	var err error
+	assert := assert.New(t)
+
+	client = New(token)
+	client.BaseURL = "^http://localhost"
+
+	webhook, call, err := client.OutboundWebhooks.Upsert(&OutboundWebhook{})
+	assert.NotNil(err)
+	assert.IsType(&Call{}, call)
+	assert.Nil(webhook)
+}
+
+func TestOutboundWebhookServiceDelete(t *testing.T) {
+	var err error
+	assert := assert.New(t)
+
+	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal("DELETE", r.Method)
+		assert.Equal("/outbound_webhooks/1.json", r.URL.Path)
+		checkHeaders(r, assert)
+
+		w.WriteHeader(200)
+		fmt.Fprintln(w, "")
+	})
+
+	server := httptest.NewServer(handler)
+	defer server.Close()
+
+	client = New(token)
+	client.BaseURL = server.URL
+
+	call, err := client.OutboundWebhooks.Delete(1)
+	assert.Nil(err)
+	assert.IsType(&Call{}, call)
+}
+
+func TestOutboundWebhookServiceAttach(t *testing.T) {
+	var err error
+	assert := assert.New(t)
+
+	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal("PUT", r.Method)
+		assert.Equal("/stages/3/outbound_webhooks/1.json", r.URL.Path)
+		checkHeaders(r, assert)
+
+		w.WriteHeader(200)
+		fmt.Fprintln(w, "")
+	})
+
+	server := httptest.NewServer(handler)
+	defer server.Close()
+
+	client = New(token)
+	client.BaseURL = server.URL
+
+	call, err := client.OutboundWebhooks.Attach(1, 3)
+	assert.Nil(err)
+	assert.IsType(&Call{}, call)
+}
+
+func TestOutboundWebhookServiceDetach(t *testing.T) {
+	var err error
+	assert := assert.New(t)
+
+	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal("DELETE", r.Method)
+		assert.Equal("/stages/3/outbound_webhooks/1.json", r.URL.Path)
+		checkHeaders(r, assert)
+
+		w.WriteHeader(404)
+		fmt.Fprintln(w, readTestData("error-notfound.json"))
+	})
+
+	server := httptest.NewServer(handler)
+	defer server.Close()
+
+	client = New(token)
+	client.BaseURL = server.URL
+
+	call, err := client.OutboundWebhooks.Detach(1, 3)
+	assert.NotNil(err)
+	assert.Equal("Not found error", err.Error())
+	assert.IsType(&Call{}, call)
+}
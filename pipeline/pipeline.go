@@ -0,0 +1,309 @@
+// Package pipeline builds the promotion pipeline of a project, like
+// staging → canary → production, from the NextStageIds of its stages.
+//
+// A Graph validates the pipeline for duplicate stage ids, cycles, next
+// stages which don't exist in the project and production stages no deploy
+// is promoted to, orders the stages topologically and renders as Graphviz
+// DOT or Mermaid.
+package pipeline
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	samson "github.com/tolgaakyuz/samson-go"
+)
+
+// Graph is the directed graph of a project's stages, edges point from a
+// stage to its next stages
+type Graph struct {
+	stages     []*samson.Stage
+	byID       map[int]*samson.Stage
+	next       map[int][]int
+	previous   map[int][]int
+	dangling   map[int][]int
+	duplicates []*samson.Stage
+}
+
+// New builds the graph of the stages, next stages missing from them are
+// dangling. Of stages sharing an id only the first is kept, the others
+// are reported by Validate.
+func New(stages []*samson.Stage) *Graph {
+	g := &Graph{
+		byID:     map[int]*samson.Stage{},
+		next:     map[int][]int{},
+		previous: map[int][]int{},
+		dangling: map[int][]int{},
+	}
+
+	for _, stage := range stages {
+		if stage.ID == nil {
+			continue
+		}
+		if _, ok := g.byID[*stage.ID]; ok {
+			g.duplicates = append(g.duplicates, stage)
+			continue
+		}
+		g.stages = append(g.stages, stage)
+		g.byID[*stage.ID] = stage
+	}
+	sort.SliceStable(g.stages, func(i, j int) bool {
+		return less(g.stages[i], g.stages[j])
+	})
+
+	for _, stage := range g.stages {
+		for _, nextID := range stage.NextStageIds {
+			if nextID == nil {
+				continue
+			}
+			if _, ok := g.byID[*nextID]; !ok {
+				g.dangling[*stage.ID] = append(g.dangling[*stage.ID], *nextID)
+				continue
+			}
+			g.next[*stage.ID] = append(g.next[*stage.ID], *nextID)
+			g.previous[*nextID] = append(g.previous[*nextID], *stage.ID)
+		}
+	}
+
+	return g
+}
+
+// Load builds the graph of a project's stages
+func Load(client *samson.Samson, projectID int) (*Graph, error) {
+	stages, _, err := client.Stages.List()
+	if err != nil {
+		return nil, err
+	}
+
+	var project []*samson.Stage
+	for _, stage := range stages {
+		if stage.ProjectID != nil && *stage.ProjectID == projectID {
+			project = append(project, stage)
+		}
+	}
+
+	return New(project), nil
+}
+
+// Stages returns the stages ordered by their order and id
+func (g *Graph) Stages() []*samson.Stage {
+	return g.stages
+}
+
+// Next returns the next stages of a stage
+func (g *Graph) Next(stageID int) []*samson.Stage {
+	return g.lookup(g.next[stageID])
+}
+
+// Previous returns the stages a stage is the next stage of
+func (g *Graph) Previous(stageID int) []*samson.Stage {
+	return g.lookup(g.previous[stageID])
+}
+
+// Dangling returns the next stage ids of a stage which aren't in the graph
+func (g *Graph) Dangling(stageID int) []int {
+	return g.dangling[stageID]
+}
+
+func (g *Graph) lookup(ids []int) []*samson.Stage {
+	stages := make([]*samson.Stage, len(ids))
+	for i, id := range ids {
+		stages[i] = g.byID[id]
+	}
+
+	return stages
+}
+
+// CycleError is returned when stages can't be ordered
+type CycleError struct {
+	Cycles [][]*samson.Stage
+}
+
+func (e *CycleError) Error() string {
+	cycles := make([]string, len(e.Cycles))
+	for i, cycle := range e.Cycles {
+		cycles[i] = stageNames(cycle)
+	}
+
+	return "pipeline: stages form a cycle: " + strings.Join(cycles, "; ")
+}
+
+// Order returns the stages ordered so every stage precedes its next
+// stages, independent stages keep their order
+func (g *Graph) Order() ([]*samson.Stage, error) {
+	if cycles := g.Cycles(); len(cycles) > 0 {
+		return nil, &CycleError{Cycles: cycles}
+	}
+
+	incoming := map[int]int{}
+	for _, stage := range g.stages {
+		incoming[*stage.ID] = len(g.previous[*stage.ID])
+	}
+
+	order := make([]*samson.Stage, 0, len(g.stages))
+	done := map[int]bool{}
+	for len(order) < len(g.stages) {
+		// the first stage without pending previous stages keeps independent
+		// stages in their order
+		for _, stage := range g.stages {
+			id := *stage.ID
+			if done[id] || incoming[id] > 0 {
+				continue
+			}
+
+			done[id] = true
+			order = append(order, stage)
+			for _, next := range g.next[id] {
+				incoming[next]--
+			}
+			break
+		}
+	}
+
+	return order, nil
+}
+
+// Cycles returns the groups of stages which lead back to themselves
+func (g *Graph) Cycles() [][]*samson.Stage {
+	var cycles [][]*samson.Stage
+	for _, component := range g.components() {
+		id := *component[0].ID
+		if len(component) > 1 || contains(g.next[id], id) {
+			cycles = append(cycles, component)
+		}
+	}
+
+	return cycles
+}
+
+// components returns the strongly connected components of the graph
+// using Tarjan's algorithm, stages within and the components are ordered
+// by the first stage
+func (g *Graph) components() [][]*samson.Stage {
+	index := 0
+	indexes := map[int]int{}
+	lowlinks := map[int]int{}
+	onStack := map[int]bool{}
+	var stack []int
+	var components [][]*samson.Stage
+
+	var connect func(id int)
+	connect = func(id int) {
+		indexes[id] = index
+		lowlinks[id] = index
+		index++
+		stack = append(stack, id)
+		onStack[id] = true
+
+		for _, next := range g.next[id] {
+			if _, visited := indexes[next]; !visited {
+				connect(next)
+				if lowlinks[next] < lowlinks[id] {
+					lowlinks[id] = lowlinks[next]
+				}
+			} else if onStack[next] && indexes[next] < lowlinks[id] {
+				lowlinks[id] = indexes[next]
+			}
+		}
+
+		if lowlinks[id] != indexes[id] {
+			return
+		}
+
+		var component []*samson.Stage
+		for {
+			top := stack[len(stack)-1]
+			stack = stack[:len(stack)-1]
+			onStack[top] = false
+			component = append(component, g.byID[top])
+			if top == id {
+				break
+			}
+		}
+		sort.Slice(component, func(i, j int) bool {
+			return less(component[i], component[j])
+		})
+		components = append(components, component)
+	}
+
+	for _, stage := range g.stages {
+		if _, visited := indexes[*stage.ID]; !visited {
+			connect(*stage.ID)
+		}
+	}
+
+	sort.Slice(components, func(i, j int) bool {
+		return less(components[i][0], components[j][0])
+	})
+
+	return components
+}
+
+// reachable returns the stages reachable from the given ones
+func (g *Graph) reachable(from []int) map[int]bool {
+	seen := map[int]bool{}
+	queue := append([]int{}, from...)
+	for len(queue) > 0 {
+		id := queue[0]
+		queue = queue[1:]
+		if seen[id] {
+			continue
+		}
+		seen[id] = true
+		queue = append(queue, g.next[id]...)
+	}
+
+	return seen
+}
+
+func less(a, b *samson.Stage) bool {
+	if order(a) != order(b) {
+		return order(a) < order(b)
+	}
+
+	return *a.ID < *b.ID
+}
+
+func order(stage *samson.Stage) int {
+	if stage.Order == nil {
+		return 0
+	}
+
+	return *stage.Order
+}
+
+func contains(ids []int, id int) bool {
+	for _, i := range ids {
+		if i == id {
+			return true
+		}
+	}
+
+	return false
+}
+
+func isProduction(stage *samson.Stage) bool {
+	return stage.Production != nil && *stage.Production
+}
+
+// stageName names a stage by permalink, falling back to name and id
+func stageName(stage *samson.Stage) string {
+	if stage.Permalink != nil && *stage.Permalink != "" {
+		return *stage.Permalink
+	}
+	if stage.Name != nil && *stage.Name != "" {
+		return *stage.Name
+	}
+
+	return fmt.Sprintf("#%d", *stage.ID)
+}
+
+func stageNames(stages []*samson.Stage) string {
+	names := make([]string, len(stages))
+	for i, stage := range stages {
+		names[i] = stageName(stage)
+	}
+
+	return strings.Join(names, ", ")
+}
@@ -0,0 +1,195 @@
+package pipeline
+
+import (
+	"bytes"
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	samson "github.com/tolgaakyuz/samson-go"
+	"github.com/tolgaakyuz/samson-go/internal/samsontest"
+)
+
+func stages(t *testing.T, data string) []*samson.Stage {
+	var stages []*samson.Stage
+	if err := json.Unmarshal([]byte(data), &stages); err != nil {
+		t.Fatal(err)
+	}
+
+	return stages
+}
+
+func names(stages []*samson.Stage) []string {
+	names := make([]string, len(stages))
+	for i, stage := range stages {
+		names[i] = stageName(stage)
+	}
+
+	return names
+}
+
+const promotion = `[
+	{"id": 3, "name": "Production", "permalink": "production", "production": true, "order": 3},
+	{"id": 1, "name": "Staging", "permalink": "staging", "next_stage_ids": [2], "order": 1},
+	{"id": 2, "name": "Canary", "permalink": "canary", "next_stage_ids": [3], "order": 2},
+	{"id": 4, "name": "Review \"apps\"", "permalink": "review", "order": 0}
+]`
+
+func TestOrder(t *testing.T) {
+	assert := assert.New(t)
+
+	g := New(stages(t, promotion))
+	assert.Equal([]string{"review", "staging", "canary", "production"}, names(g.Stages()))
+	assert.Equal([]string{"canary"}, names(g.Next(1)))
+	assert.Equal([]string{"canary"}, names(g.Previous(3)))
+
+	order, err := g.Order()
+	assert.Nil(err)
+	assert.Equal([]string{"review", "staging", "canary", "production"}, names(order))
+	assert.Empty(g.Validate())
+
+	// next stages come after their previous stages whatever their order
+	g = New(stages(t, `[
+		{"id": 1, "permalink": "production", "production": true, "order": 1},
+		{"id": 2, "permalink": "staging", "next_stage_ids": [1], "order": 2}
+	]`))
+	order, err = g.Order()
+	assert.Nil(err)
+	assert.Equal([]string{"staging", "production"}, names(order))
+}
+
+func TestValidate(t *testing.T) {
+	assert := assert.New(t)
+
+	g := New(stages(t, `[
+		{"id": 1, "permalink": "staging", "next_stage_ids": [2, 9]},
+		{"id": 2, "permalink": "canary", "next_stage_ids": [3]},
+		{"id": 3, "permalink": "production", "production": true, "next_stage_ids": [2]},
+		{"id": 4, "permalink": "loop", "next_stage_ids": [4]},
+		{"id": 5, "permalink": "hotfix", "production": true}
+	]`))
+
+	assert.Equal([][]string{{"canary", "production"}, {"loop"}}, [][]string{names(g.Cycles()[0]), names(g.Cycles()[1])})
+	assert.Equal([]int{9}, g.Dangling(1))
+
+	var problems []string
+	for _, problem := range g.Validate() {
+		problems = append(problems, problem.String())
+	}
+	assert.Equal([]string{
+		"cycle: stages canary, production lead back to themselves",
+		"cycle: stages loop lead back to themselves",
+		"dangling stage: stage staging has next stages 9 which are not in the project",
+		"unreachable production: production stage hotfix is not promoted to from a non production stage",
+	}, problems)
+
+	_, err := g.Order()
+	assert.EqualError(err, "pipeline: stages form a cycle: canary, production; loop")
+
+	// without a pipeline a production stage needs no previous stage
+	assert.Empty(New(stages(t, `[{"id": 1, "permalink": "production", "production": true}]`)).Validate())
+}
+
+func TestDuplicateStages(t *testing.T) {
+	assert := assert.New(t)
+
+	g := New(stages(t, `[
+		{"id": 1, "permalink": "staging", "next_stage_ids": [2]},
+		{"id": 2, "permalink": "production", "production": true},
+		{"id": 1, "permalink": "copy", "next_stage_ids": [2]}
+	]`))
+	assert.Equal([]string{"staging", "production"}, names(g.Stages()))
+	assert.Equal([]string{"staging"}, names(g.Previous(2)))
+
+	order, err := g.Order()
+	assert.Nil(err)
+	assert.Equal([]string{"staging", "production"}, names(order))
+
+	problems := g.Validate()
+	assert.Len(problems, 1)
+	assert.Equal(DuplicateStage, problems[0].Kind)
+	assert.Equal([]string{"staging", "copy"}, names(problems[0].Stages))
+	assert.Equal("duplicate stage: stage copy has the id 1 of stage staging and is ignored", problems[0].String())
+}
+
+func TestWriteDOT(t *testing.T) {
+	assert := assert.New(t)
+
+	g := New(stages(t, promotion))
+	g.stages[1].NextStageIds = append(g.stages[1].NextStageIds, samson.Int(8))
+	g = New(g.stages)
+
+	var buf bytes.Buffer
+	assert.Nil(g.WriteDOT(&buf))
+	assert.Equal(`digraph pipeline {
+  rankdir=LR;
+  stage_4 [label="Review \"apps\""];
+  stage_1 [label="Staging"];
+  stage_2 [label="Canary"];
+  stage_3 [label="Production", peripheries=2];
+  stage_8 [label="#8", style=dashed];
+  stage_1 -> stage_2;
+  stage_1 -> stage_8 [style=dashed];
+  stage_2 -> stage_3;
+}
+`, buf.String())
+}
+
+func TestWriteDOTEscaping(t *testing.T) {
+	assert := assert.New(t)
+
+	g := New([]*samson.Stage{{ID: samson.Int(1), Name: samson.String("Pré-prod \\ \"eu\"\nwest\a")}})
+
+	var buf bytes.Buffer
+	assert.Nil(g.WriteDOT(&buf))
+	// only quotes, backslashes and newlines are escaped, no Go escapes
+	assert.Contains(buf.String(), `  stage_1 [label="Pré-prod \\ \"eu\"\nwest`+"\a"+`"];`)
+}
+
+func TestWriteMermaid(t *testing.T) {
+	assert := assert.New(t)
+
+	g := New(stages(t, promotion))
+	g.stages[1].NextStageIds = append(g.stages[1].NextStageIds, samson.Int(8))
+	g = New(g.stages)
+
+	var buf bytes.Buffer
+	assert.Nil(g.WriteMermaid(&buf))
+	assert.Equal(`graph LR
+  stage_4["Review #quot;apps#quot;"]
+  stage_1["Staging"]
+  stage_2["Canary"]
+  stage_3["Production"]
+  stage_8["#8"]
+  stage_1 --> stage_2
+  stage_1 -.-> stage_8
+  stage_2 --> stage_3
+  classDef production stroke-width:3px
+  class stage_3 production
+  classDef dangling stroke-dasharray:5 5
+  class stage_8 dangling
+`, buf.String())
+}
+
+func TestLoad(t *testing.T) {
+	assert := assert.New(t)
+
+	fake := samsontest.New()
+	fake.Add("stages", `{"id": 1, "permalink": "staging", "project_id": 7, "next_stage_ids": [2]}`)
+	fake.Add("stages", `{"id": 2, "permalink": "production", "project_id": 7, "production": true}`)
+	fake.Add("stages", `{"id": 3, "permalink": "other", "project_id": 8, "next_stage_ids": [1]}`)
+	server := fake.Start()
+	defer server.Close()
+
+	client := samson.New("token")
+	client.BaseURL = server.URL
+
+	g, err := Load(client, 7)
+	assert.Nil(err)
+	assert.Equal([]string{"staging", "production"}, names(g.Stages()))
+	assert.Empty(g.Validate())
+
+	fake.Fail = "GET /stages.json"
+	_, err = Load(client, 7)
+	assert.NotNil(err)
+}
@@ -0,0 +1,126 @@
+package pipeline
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"strings"
+
+	samson "github.com/tolgaakyuz/samson-go"
+)
+
+// WriteDOT renders the graph as Graphviz DOT, production stages are drawn
+// with a double border and dangling next stages dashed
+func (g *Graph) WriteDOT(w io.Writer) error {
+	out := bufio.NewWriter(w)
+
+	fmt.Fprintln(out, "digraph pipeline {")
+	fmt.Fprintln(out, "  rankdir=LR;")
+	for _, stage := range g.stages {
+		attributes := "label=" + dotQuote(label(stage))
+		if isProduction(stage) {
+			attributes += ", peripheries=2"
+		}
+		fmt.Fprintf(out, "  %s [%s];\n", nodeID(*stage.ID), attributes)
+	}
+	for _, id := range g.danglingIDs() {
+		fmt.Fprintf(out, "  %s [label=%s, style=dashed];\n", nodeID(id), dotQuote(fmt.Sprintf("#%d", id)))
+	}
+	for _, stage := range g.stages {
+		for _, next := range g.next[*stage.ID] {
+			fmt.Fprintf(out, "  %s -> %s;\n", nodeID(*stage.ID), nodeID(next))
+		}
+		for _, next := range g.dangling[*stage.ID] {
+			fmt.Fprintf(out, "  %s -> %s [style=dashed];\n", nodeID(*stage.ID), nodeID(next))
+		}
+	}
+	fmt.Fprintln(out, "}")
+
+	return out.Flush()
+}
+
+// WriteMermaid renders the graph as a Mermaid flowchart, production stages
+// are of class production and dangling next stages of class dangling
+func (g *Graph) WriteMermaid(w io.Writer) error {
+	out := bufio.NewWriter(w)
+
+	fmt.Fprintln(out, "graph LR")
+	for _, stage := range g.stages {
+		fmt.Fprintf(out, "  %s[\"%s\"]\n", nodeID(*stage.ID), mermaidEscape(label(stage)))
+	}
+	for _, id := range g.danglingIDs() {
+		fmt.Fprintf(out, "  %s[\"#%d\"]\n", nodeID(id), id)
+	}
+	for _, stage := range g.stages {
+		for _, next := range g.next[*stage.ID] {
+			fmt.Fprintf(out, "  %s --> %s\n", nodeID(*stage.ID), nodeID(next))
+		}
+		for _, next := range g.dangling[*stage.ID] {
+			fmt.Fprintf(out, "  %s -.-> %s\n", nodeID(*stage.ID), nodeID(next))
+		}
+	}
+
+	var production []string
+	for _, stage := range g.stages {
+		if isProduction(stage) {
+			production = append(production, nodeID(*stage.ID))
+		}
+	}
+	if len(production) > 0 {
+		fmt.Fprintln(out, "  classDef production stroke-width:3px")
+		fmt.Fprintf(out, "  class %s production\n", strings.Join(production, ","))
+	}
+
+	var dangling []string
+	for _, id := range g.danglingIDs() {
+		dangling = append(dangling, nodeID(id))
+	}
+	if len(dangling) > 0 {
+		fmt.Fprintln(out, "  classDef dangling stroke-dasharray:5 5")
+		fmt.Fprintf(out, "  class %s dangling\n", strings.Join(dangling, ","))
+	}
+
+	return out.Flush()
+}
+
+// danglingIDs returns the distinct dangling next stage ids in stage order
+func (g *Graph) danglingIDs() []int {
+	var ids []int
+	seen := map[int]bool{}
+	for _, stage := range g.stages {
+		for _, id := range g.dangling[*stage.ID] {
+			if !seen[id] {
+				seen[id] = true
+				ids = append(ids, id)
+			}
+		}
+	}
+
+	return ids
+}
+
+func nodeID(id int) string {
+	return fmt.Sprintf("stage_%d", id)
+}
+
+func label(stage *samson.Stage) string {
+	if stage.Name != nil && *stage.Name != "" {
+		return *stage.Name
+	}
+
+	return stageName(stage)
+}
+
+// dotEscaper escapes what DOT strings can't hold literally, newlines become
+// DOT's centered line breaks
+var dotEscaper = strings.NewReplacer(`\`, `\\`, `"`, `\"`, "\r\n", `\n`, "\n", `\n`, "\r", `\n`)
+
+// dotQuote returns s as a quoted DOT string, other characters like
+// unicode are written as they are
+func dotQuote(s string) string {
+	return `"` + dotEscaper.Replace(s) + `"`
+}
+
+func mermaidEscape(s string) string {
+	return strings.Replace(s, `"`, "#quot;", -1)
+}
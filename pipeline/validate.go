@@ -0,0 +1,95 @@
+package pipeline
+
+import (
+	"fmt"
+	"strings"
+
+	samson "github.com/tolgaakyuz/samson-go"
+)
+
+// Kind of a pipeline problem
+type Kind string
+
+// Kinds
+const (
+	Cycle                 Kind = "cycle"
+	DanglingStage         Kind = "dangling stage"
+	UnreachableProduction Kind = "unreachable production"
+	DuplicateStage        Kind = "duplicate stage"
+)
+
+// Problem is an issue with the pipeline
+type Problem struct {
+	Kind    Kind
+	Stages  []*samson.Stage
+	Message string
+}
+
+func (p Problem) String() string {
+	return fmt.Sprintf("%s: %s", p.Kind, p.Message)
+}
+
+// Validate returns the problems of the pipeline: stages sharing an id,
+// cycles, next stage ids missing from the project and, when any stage has
+// next stages, production stages which can't be reached from a non
+// production stage without previous stages
+func (g *Graph) Validate() []Problem {
+	var problems []Problem
+
+	for _, stage := range g.duplicates {
+		problems = append(problems, Problem{
+			Kind:    DuplicateStage,
+			Stages:  []*samson.Stage{g.byID[*stage.ID], stage},
+			Message: fmt.Sprintf("stage %s has the id %d of stage %s and is ignored", stageName(stage), *stage.ID, stageName(g.byID[*stage.ID])),
+		})
+	}
+
+	for _, cycle := range g.Cycles() {
+		problems = append(problems, Problem{
+			Kind:    Cycle,
+			Stages:  cycle,
+			Message: "stages " + stageNames(cycle) + " lead back to themselves",
+		})
+	}
+
+	pipeline := false
+	for _, stage := range g.stages {
+		id := *stage.ID
+		if len(g.next[id]) > 0 {
+			pipeline = true
+		}
+		if dangling := g.dangling[id]; len(dangling) > 0 {
+			ids := make([]string, len(dangling))
+			for i, danglingID := range dangling {
+				ids[i] = fmt.Sprint(danglingID)
+			}
+			problems = append(problems, Problem{
+				Kind:    DanglingStage,
+				Stages:  []*samson.Stage{stage},
+				Message: fmt.Sprintf("stage %s has next stages %s which are not in the project", stageName(stage), strings.Join(ids, ", ")),
+			})
+		}
+	}
+	if !pipeline {
+		return problems
+	}
+
+	var entries []int
+	for _, stage := range g.stages {
+		if len(g.previous[*stage.ID]) == 0 && !isProduction(stage) {
+			entries = append(entries, *stage.ID)
+		}
+	}
+	reachable := g.reachable(entries)
+	for _, stage := range g.stages {
+		if isProduction(stage) && !reachable[*stage.ID] {
+			problems = append(problems, Problem{
+				Kind:    UnreachableProduction,
+				Stages:  []*samson.Stage{stage},
+				Message: fmt.Sprintf("production stage %s is not promoted to from a non production stage", stageName(stage)),
+			})
+		}
+	}
+
+	return problems
+}
@@ -19,6 +19,7 @@ type Project struct {
 	ReleaseBranch                          *string                `json:"release_branch,omitempty"`
 	Permalink                              *string                `json:"permalink,omitempty"`
 	EnvironmentVariableAttributes          []*EnvironmentVariable `json:"environment_variables_attributes,omitempty"`
+	EnvironmentVariableGroupIds            []*int                 `json:"environment_variable_group_ids,omitempty"`
 	IncludeNewDeployGroups                 *bool                  `json:"include_new_deploy_groups,omitempty"`
 	DockerReleaseBranch                    *string                `json:"docker_release_branch,omitempty"`
 	DockerImageBuildingDisabled            *bool                  `json:"docker_image_building_disabled,omitempty"`
@@ -31,15 +32,22 @@ type Project struct {
 	Dashboard                              *string                `json:"dashboard,omitempty"`
 	RepositoryPath                         *string                `json:"repository_path,omitempty"`
 	Owner                                  *string                `json:"owner,omitempty"`
+	Token                                  *string                `json:"token,omitempty"`
+	StarCount                              *int                   `json:"star_count,omitempty"`
+	LastDeployedAt                         *time.Time             `json:"last_deployed_at,omitempty"`
+	LastDeployedBy                         *string                `json:"last_deployed_by,omitempty"`
+	LastDeployURL                          *string                `json:"last_deploy_url,omitempty"`
 	CreatedAt                              *time.Time             `json:"created_at,omitempty"`
 	UpdatedAt                              *time.Time             `json:"updated_at,omitempty"`
 }
 
-// EnvironmentVariable model for projects
+// EnvironmentVariable model for projects and environment variable groups
 type EnvironmentVariable struct {
+	ID             *int    `json:"id,omitempty"`
 	Name           *string `json:"name,omitempty"`
 	Value          *string `json:"value,omitempty"`
 	ScopeTypeAndID *string `json:"scope_type_and_id,omitempty"`
+	Destroy        *bool   `json:"_destroy,omitempty"`
 }
 
 // List returns all projects
@@ -123,3 +131,33 @@ func (service *ProjectService) Delete(id int) (*Call, error) {
 
 	return call, call.Do(nil)
 }
+
+// SetEnvironmentVariableGroups replaces the environment variable groups a project includes
+func (service *ProjectService) SetEnvironmentVariableGroups(id int, groupIDs []int) (*Project, *Call, error) {
+	ids := make([]*int, len(groupIDs))
+	for i := range groupIDs {
+		ids[i] = Int(groupIDs[i])
+	}
+
+	// an empty list has to be sent explicitly to remove all groups
+	type request struct {
+		EnvironmentVariableGroupIds []*int `json:"environment_variable_group_ids"`
+	}
+	bytesArray, _ := json.Marshal(request{EnvironmentVariableGroupIds: ids})
+
+	path := fmt.Sprintf("/projects/%d.json", id)
+	method := "PUT"
+
+	call, err := service.s.NewCall(method, path, nil, nil, bytes.NewReader(bytesArray))
+	if err != nil {
+		return nil, call, err
+	}
+
+	var project Project
+	err = call.Do(&project)
+	if err != nil {
+		return nil, call, err
+	}
+
+	return &project, call, nil
+}
@@ -0,0 +1,200 @@
+package samson
+
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+)
+
+// CPU is a kubernetes cpu quantity in cores
+// It is encoded as a number of cores and decodes numbers or quantities like 500m
+type CPU float64
+
+// ParseCPU parses a kubernetes cpu quantity like 2, 0.5 or 500m
+func ParseCPU(s string) (CPU, error) {
+	number := strings.TrimSpace(s)
+
+	divisor := 1.0
+	if strings.HasSuffix(number, "m") {
+		number = strings.TrimSuffix(number, "m")
+		divisor = 1000
+	}
+
+	value, err := parseQuantity(number)
+	if err != nil {
+		return 0, fmt.Errorf("invalid cpu quantity %q", s)
+	}
+
+	return CPU(value / divisor), nil
+}
+
+// String returns the quantity in cores, or in millicores for fractions
+func (c CPU) String() string {
+	if c == CPU(math.Trunc(float64(c))) {
+		return strconv.FormatFloat(float64(c), 'f', -1, 64)
+	}
+
+	return strconv.FormatFloat(math.Round(float64(c)*1000), 'f', -1, 64) + "m"
+}
+
+// MarshalJSON encodes the quantity as a number of cores
+func (c CPU) MarshalJSON() ([]byte, error) {
+	return json.Marshal(float64(c))
+}
+
+// UnmarshalJSON decodes a number of cores or a quantity string
+func (c *CPU) UnmarshalJSON(data []byte) error {
+	var value float64
+	if err := json.Unmarshal(data, &value); err == nil {
+		if !validQuantity(value) {
+			return fmt.Errorf("invalid cpu quantity %s", data)
+		}
+		*c = CPU(value)
+		return nil
+	}
+
+	var s string
+	if err := json.Unmarshal(data, &s); err != nil {
+		return fmt.Errorf("invalid cpu quantity %s", data)
+	}
+
+	cpu, err := ParseCPU(s)
+	if err != nil {
+		return err
+	}
+
+	*c = cpu
+	return nil
+}
+
+// Memory is a kubernetes memory quantity in bytes
+// Samson stores memory in mebibytes, so it is encoded as a number of mebibytes
+// and decodes numbers of mebibytes or quantities like 512Mi and 1G
+type Memory int64
+
+// Memory units
+const (
+	Byte     Memory = 1
+	Kilobyte        = 1000 * Byte
+	Megabyte        = 1000 * Kilobyte
+	Gigabyte        = 1000 * Megabyte
+	Terabyte        = 1000 * Gigabyte
+	Kibibyte        = 1024 * Byte
+	Mebibyte        = 1024 * Kibibyte
+	Gibibyte        = 1024 * Mebibyte
+	Tebibyte        = 1024 * Gibibyte
+)
+
+var memorySuffixes = []struct {
+	suffix string
+	unit   Memory
+}{
+	// binary suffixes first, so Mi is not parsed as M
+	{"Ki", Kibibyte},
+	{"Mi", Mebibyte},
+	{"Gi", Gibibyte},
+	{"Ti", Tebibyte},
+	{"k", Kilobyte},
+	{"K", Kilobyte},
+	{"M", Megabyte},
+	{"G", Gigabyte},
+	{"T", Terabyte},
+}
+
+// ParseMemory parses a kubernetes memory quantity like 128974848, 512Mi or 1.5G
+// Quantities without a unit are bytes
+func ParseMemory(s string) (Memory, error) {
+	quantity := strings.TrimSpace(s)
+
+	unit := Byte
+	number := quantity
+	for _, suffix := range memorySuffixes {
+		if strings.HasSuffix(quantity, suffix.suffix) {
+			unit = suffix.unit
+			number = strings.TrimSuffix(quantity, suffix.suffix)
+			break
+		}
+	}
+
+	value, err := parseQuantity(number)
+	if err != nil {
+		return 0, fmt.Errorf("invalid memory quantity %q", s)
+	}
+
+	return Memory(math.Round(value * float64(unit))), nil
+}
+
+// String returns the quantity with the largest binary suffix that fits exactly
+func (m Memory) String() string {
+	for i := 3; i >= 0; i-- {
+		unit := memorySuffixes[i].unit
+		if m != 0 && m%unit == 0 {
+			return strconv.FormatInt(int64(m/unit), 10) + memorySuffixes[i].suffix
+		}
+	}
+
+	return strconv.FormatInt(int64(m), 10)
+}
+
+// Mebibytes returns the quantity in mebibytes, rounded up
+func (m Memory) Mebibytes() int64 {
+	return int64((m + Mebibyte - 1) / Mebibyte)
+}
+
+// MarshalJSON encodes the quantity as a number of mebibytes
+func (m Memory) MarshalJSON() ([]byte, error) {
+	return json.Marshal(m.Mebibytes())
+}
+
+// UnmarshalJSON decodes a number of mebibytes or a quantity string,
+// strings without a unit like "512" are mebibytes as well
+func (m *Memory) UnmarshalJSON(data []byte) error {
+	var value float64
+	if err := json.Unmarshal(data, &value); err == nil {
+		if !validQuantity(value) {
+			return fmt.Errorf("invalid memory quantity %s", data)
+		}
+		*m = Memory(math.Round(value * float64(Mebibyte)))
+		return nil
+	}
+
+	var s string
+	if err := json.Unmarshal(data, &s); err != nil {
+		return fmt.Errorf("invalid memory quantity %s", data)
+	}
+
+	if value, err := parseQuantity(strings.TrimSpace(s)); err == nil {
+		*m = Memory(math.Round(value * float64(Mebibyte)))
+		return nil
+	}
+
+	memory, err := ParseMemory(s)
+	if err != nil {
+		return err
+	}
+
+	*m = memory
+	return nil
+}
+
+// parseQuantity parses the number of a quantity, rejecting negative,
+// infinite and NaN values
+func parseQuantity(s string) (float64, error) {
+	value, err := strconv.ParseFloat(s, 64)
+	if err != nil {
+		return 0, err
+	}
+	if !validQuantity(value) {
+		return 0, fmt.Errorf("invalid quantity %q", s)
+	}
+
+	return value, nil
+}
+
+// validQuantity returns whether a value is neither negative, infinite
+// nor NaN
+func validQuantity(value float64) bool {
+	return value >= 0 && !math.IsNaN(value) && !math.IsInf(value, 0)
+}
@@ -0,0 +1,124 @@
+package samson
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseCPU(t *testing.T) {
+	assert := assert.New(t)
+
+	tests := map[string]CPU{
+		"2":     2,
+		"0.5":   0.5,
+		"500m":  0.5,
+		"250m":  0.25,
+		" 1.5 ": 1.5,
+	}
+	for s, expected := range tests {
+		cpu, err := ParseCPU(s)
+		assert.Nil(err, s)
+		assert.Equal(expected, cpu, s)
+	}
+
+	for _, s := range []string{"", "m", "two", "-1", "1Gi", "NaN", "Inf", "-Inf", "infm"} {
+		_, err := ParseCPU(s)
+		assert.NotNil(err, s)
+	}
+
+	_, err := ParseCPU(" -500m ")
+	assert.EqualError(err, `invalid cpu quantity " -500m "`)
+}
+
+func TestCPUString(t *testing.T) {
+	assert := assert.New(t)
+
+	assert.Equal("2", CPU(2).String())
+	assert.Equal("500m", CPU(0.5).String())
+	assert.Equal("1500m", CPU(1.5).String())
+	assert.Equal("0", CPU(0).String())
+}
+
+func TestCPUJSON(t *testing.T) {
+	assert := assert.New(t)
+
+	var cpus []CPU
+	err := json.Unmarshal([]byte(`[0.25, "250m", 1]`), &cpus)
+	assert.Nil(err)
+	assert.Equal([]CPU{0.25, 0.25, 1}, cpus)
+
+	bytesArray, err := json.Marshal(cpus)
+	assert.Nil(err)
+	assert.Equal(`[0.25,0.25,1]`, string(bytesArray))
+
+	var cpu CPU
+	assert.NotNil(json.Unmarshal([]byte(`"quarter"`), &cpu))
+	assert.NotNil(json.Unmarshal([]byte(`"NaN"`), &cpu))
+	assert.EqualError(json.Unmarshal([]byte(`-1`), &cpu), "invalid cpu quantity -1")
+	assert.NotNil(json.Unmarshal([]byte(`"-1"`), &cpu))
+	assert.NotNil(json.Unmarshal([]byte(`true`), &cpu))
+}
+
+func TestParseMemory(t *testing.T) {
+	assert := assert.New(t)
+
+	tests := map[string]Memory{
+		"128974848": 128974848,
+		"512Mi":     512 * Mebibyte,
+		"1Gi":       Gibibyte,
+		"1.5Gi":     1536 * Mebibyte,
+		"1G":        Gigabyte,
+		"129M":      129 * Megabyte,
+		"123k":      123 * Kilobyte,
+		"2Ki":       2 * Kibibyte,
+	}
+	for s, expected := range tests {
+		memory, err := ParseMemory(s)
+		assert.Nil(err, s)
+		assert.Equal(expected, memory, s)
+	}
+
+	for _, s := range []string{"", "Mi", "lots", "-1Gi", "1Xi", "NaN", "+Inf", "InfGi"} {
+		_, err := ParseMemory(s)
+		assert.NotNil(err, s)
+	}
+}
+
+func TestMemoryString(t *testing.T) {
+	assert := assert.New(t)
+
+	assert.Equal("512Mi", (512 * Mebibyte).String())
+	assert.Equal("1Gi", Gibibyte.String())
+	assert.Equal("1536Mi", (1536 * Mebibyte).String())
+	assert.Equal("1000000000", Gigabyte.String())
+	assert.Equal("0", Memory(0).String())
+}
+
+func TestMemoryMebibytes(t *testing.T) {
+	assert := assert.New(t)
+
+	assert.Equal(int64(512), (512 * Mebibyte).Mebibytes())
+	assert.Equal(int64(954), Gigabyte.Mebibytes())
+}
+
+func TestMemoryJSON(t *testing.T) {
+	assert := assert.New(t)
+
+	var memories []Memory
+	err := json.Unmarshal([]byte(`[512, "512", " 256 ", "512Mi", "1Gi"]`), &memories)
+	assert.Nil(err)
+	assert.Equal([]Memory{512 * Mebibyte, 512 * Mebibyte, 256 * Mebibyte, 512 * Mebibyte, Gibibyte}, memories)
+
+	bytesArray, err := json.Marshal(memories)
+	assert.Nil(err)
+	assert.Equal(`[512,512,256,512,1024]`, string(bytesArray))
+
+	var memory Memory
+	assert.NotNil(json.Unmarshal([]byte(`"lots"`), &memory))
+	assert.NotNil(json.Unmarshal([]byte(`"NaN"`), &memory))
+	assert.EqualError(json.Unmarshal([]byte(`-512`), &memory), "invalid memory quantity -512")
+	assert.NotNil(json.Unmarshal([]byte(`"-512"`), &memory))
+	assert.NotNil(json.Unmarshal([]byte(`{}`), &memory))
+}
@@ -0,0 +1,44 @@
+package samson
+
+import "time"
+
+// States of a reference and of its individual checks
+const (
+	RefStateSuccess = "success"
+	RefStatePending = "pending"
+	RefStateFailure = "failure"
+	RefStateError   = "error"
+	RefStateMissing = "missing"
+)
+
+// RefStatus model for the combined status of a git reference for a stage
+type RefStatus struct {
+	State    *string         `json:"state,omitempty"`
+	Statuses []*CommitStatus `json:"statuses,omitempty"`
+}
+
+// CommitStatus model for a single check of a reference, e.g. a CI build or github status
+type CommitStatus struct {
+	State       *string    `json:"state,omitempty"`
+	Context     *string    `json:"context,omitempty"`
+	Description *string    `json:"description,omitempty"`
+	TargetURL   *string    `json:"target_url,omitempty"`
+	UpdatedAt   *time.Time `json:"updated_at,omitempty"`
+}
+
+// IsDeployable returns whether all checks of the reference succeeded
+func (rs *RefStatus) IsDeployable() bool {
+	return rs.State != nil && *rs.State == RefStateSuccess
+}
+
+// Failed returns the checks which did not succeed
+func (rs *RefStatus) Failed() []*CommitStatus {
+	failed := []*CommitStatus{}
+	for _, status := range rs.Statuses {
+		if status.State == nil || *status.State != RefStateSuccess {
+			failed = append(failed, status)
+		}
+	}
+
+	return failed
+}
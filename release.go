@@ -0,0 +1,157 @@
+package samson
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"time"
+)
+
+// ReleaseService service
+type ReleaseService service
+
+// Release model
+type Release struct {
+	ID         *int       `json:"id,omitempty"`
+	ProjectID  *int       `json:"project_id,omitempty"`
+	Commit     *string    `json:"commit,omitempty"`
+	Number     *string    `json:"number,omitempty"`
+	AuthorID   *int       `json:"author_id,omitempty"`
+	AuthorType *string    `json:"author_type,omitempty"`
+	CreatedAt  *time.Time `json:"created_at,omitempty"`
+	UpdatedAt  *time.Time `json:"updated_at,omitempty"`
+}
+
+// Version returns the version name of the release, e.g. v12
+func (r *Release) Version() string {
+	if r.Number == nil {
+		return ""
+	}
+
+	return "v" + *r.Number
+}
+
+// List returns all releases of a project
+func (service *ReleaseService) List(projectID int) ([]*Release, *Call, error) {
+	path := fmt.Sprintf("/projects/%d/releases.json", projectID)
+	method := "GET"
+
+	call, err := service.s.NewCall(method, path, nil, nil, nil)
+	if err != nil {
+		return nil, call, err
+	}
+
+	type response struct {
+		Releases []*Release `json:"releases,omitempty"`
+	}
+	var res response
+	err = call.Do(&res)
+	if err != nil {
+		return nil, call, err
+	}
+
+	return res.Releases, call, nil
+}
+
+// Get returns a single release resource of a project
+func (service *ReleaseService) Get(projectID int, id int) (*Release, *Call, error) {
+	path := fmt.Sprintf("/projects/%d/releases/%d.json", projectID, id)
+	method := "GET"
+
+	call, err := service.s.NewCall(method, path, nil, nil, nil)
+	if err != nil {
+		return nil, call, err
+	}
+
+	var release Release
+	err = call.Do(&release)
+	if err != nil {
+		return nil, call, err
+	}
+
+	return &release, call, nil
+}
+
+// Create creates a new release from a commit of a project
+// Releases can not be updated once they are created
+func (service *ReleaseService) Create(projectID int, release *Release) (*Release, *Call, error) {
+	bytesArray, _ := json.Marshal(release)
+
+	path := fmt.Sprintf("/projects/%d/releases.json", projectID)
+	method := "POST"
+
+	call, err := service.s.NewCall(method, path, nil, nil, bytes.NewReader(bytesArray))
+	if err != nil {
+		return nil, call, err
+	}
+
+	err = call.Do(&release)
+	if err != nil {
+		return nil, call, err
+	}
+
+	return release, call, nil
+}
+
+// Changeset returns the changeset between the release and the previous one
+func (service *ReleaseService) Changeset(projectID int, id int) (*Changeset, *Call, error) {
+	path := fmt.Sprintf("/projects/%d/releases/%d/changeset.json", projectID, id)
+	method := "GET"
+
+	call, err := service.s.NewCall(method, path, nil, nil, nil)
+	if err != nil {
+		return nil, call, err
+	}
+
+	var changeset Changeset
+	err = call.Do(&changeset)
+	if err != nil {
+		return nil, call, err
+	}
+
+	return &changeset, call, nil
+}
+
+// Deploys returns the deploys of a release
+func (service *ReleaseService) Deploys(projectID int, id int) ([]*Deploy, *Call, error) {
+	path := fmt.Sprintf("/projects/%d/releases/%d/deploys.json", projectID, id)
+	method := "GET"
+
+	call, err := service.s.NewCall(method, path, nil, nil, nil)
+	if err != nil {
+		return nil, call, err
+	}
+
+	type response struct {
+		Deploys []*Deploy `json:"deploys,omitempty"`
+	}
+	var res response
+	err = call.Do(&res)
+	if err != nil {
+		return nil, call, err
+	}
+
+	return res.Deploys, call, nil
+}
+
+// AutoDeployStages returns the stages of a project which are deployed
+// automatically when a new release is created
+func (service *ReleaseService) AutoDeployStages(projectID int) ([]*Stage, *Call, error) {
+	stages, call, err := service.s.Stages.List()
+	if err != nil {
+		return nil, call, err
+	}
+
+	autoDeployStages := []*Stage{}
+	for _, stage := range stages {
+		if stage.ProjectID == nil || *stage.ProjectID != projectID {
+			continue
+		}
+
+		if stage.DeployOnRelease != nil && *stage.DeployOnRelease {
+			autoDeployStages = append(autoDeployStages, stage)
+		}
+	}
+
+	return autoDeployStages, call, nil
+}
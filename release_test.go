@@ -0,0 +1,282 @@
+package samson
+
+import (
+	"encoding/json"
+	"fmt"
+	"log"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func ExampleReleaseService_List() {
+	client := New("token")
+
+	releases, _, err := client.Releases.List(2)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	for _, release := range releases {
+		fmt.Println(*release.ID, release.Version())
+	}
+}
+
+func ExampleReleaseService_Create() {
+	client := New("token")
+
+	release := &Release{
+		Commit: String("a4d6e3b7b9e0c1f2d3a4b5c6d7e8f9a0b1c2d3e4"),
+	}
+
+	release, _, err := client.Releases.Create(2, release)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	stages, _, err := client.Releases.AutoDeployStages(2)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	for _, stage := range stages {
+		fmt.Println(release.Version(), "will be deployed to", *stage.Name)
+	}
+}
+
+func TestReleaseServiceList(t *testing.T) {
+	var err error
+	assert := assert.New(t)
+
+	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal("GET", r.Method)
+		assert.Equal("/projects/2/releases.json", r.URL.Path)
+		checkHeaders(r, assert)
+
+		w.WriteHeader(200)
+		fmt.Fprintln(w, readTestData("releases.json"))
+	})
+
+	server := httptest.NewServer(handler)
+	defer server.Close()
+
+	client = New(token)
+	client.BaseURL = server.URL
+
+	releases, call, err := client.Releases.List(2)
+	assert.Nil(err)
+	assert.Equal(2, len(releases))
+	assert.Equal("v2", releases[0].Version())
+	assert.IsType(&Call{}, call)
+}
+
+func TestReleaseServiceList_fail(t *testing.T) {
+	var err error
+	assert := assert.New(t)
+
+	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(200)
+		fmt.Fprintln(w, "malformed json response")
+	})
+
+	server := httptest.NewServer(handler)
+	defer server.Close()
+
+	client = New(token)
+	client.BaseURL = server.URL
+
+	releases, call, err := client.Releases.List(2)
+	assert.NotNil(err)
+	assert.IsType(&Call{}, call)
+	assert.Nil(releases)
+}
+
+func TestReleaseServiceGet(t *testing.T) {
+	var err error
+	assert := assert.New(t)
+
+	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal("GET", r.Method)
+		assert.Equal("/projects/2/releases/2.json", r.URL.Path)
+		checkHeaders(r, assert)
+
+		w.WriteHeader(200)
+		fmt.Fprintln(w, readTestData("release.json"))
+	})
+
+	server := httptest.NewServer(handler)
+	defer server.Close()
+
+	client = New(token)
+	client.BaseURL = server.URL
+
+	release, call, err := client.Releases.Get(2, 2)
+	assert.Nil(err)
+	assert.Equal(2, *release.ID)
+	assert.Equal("a4d6e3b7b9e0c1f2d3a4b5c6d7e8f9a0b1c2d3e4", *release.Commit)
+	assert.IsType(&Call{}, call)
+}
+
+func TestReleaseServiceGet_fail(t *testing.T) {
+	var err error
+	assert := assert.New(t)
+
+	client = New(token)
+	client.BaseURL = "^http://localhost"
+
+	release, call, err := client.Releases.Get(2, 2)
+	assert.NotNil(err)
+	assert.IsType(&Call{}, call)
+	assert.Nil(release)
+}
+
+func TestReleaseServiceCreate(t *testing.T) {
+	var err error
+	assert := assert.New(t)
+
+	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal("POST", r.Method)
+		assert.Equal("/projects/2/releases.json", r.URL.Path)
+		checkHeaders(r, assert)
+
+		var payload map[string]interface{}
+		err := json.NewDecoder(r.Body).Decode(&payload)
+		assert.Nil(err)
+		assert.Equal("a4d6e3b7b9e0c1f2d3a4b5c6d7e8f9a0b1c2d3e4", payload["commit"])
+
+		w.WriteHeader(200)
+		fmt.Fprintln(w, readTestData("release.json"))
+	})
+
+	server := httptest.NewServer(handler)
+	defer server.Close()
+
+	client = New(token)
+	client.BaseURL = server.URL
+
+	release := &Release{
+		Commit: String("a4d6e3b7b9e0c1f2d3a4b5c6d7e8f9a0b1c2d3e4"),
+	}
+
+	release, call, err := client.Releases.Create(2, release)
+	assert.Nil(err)
+	assert.IsType(&Call{}, call)
+	assert.Equal("v2", release.Version())
+	assert.NotNil(release.CreatedAt)
+}
+
+func TestReleaseServiceCreate_fail(t *testing.T) {
+	var err error
+	assert := assert.New(t)
+
+	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(422)
+		fmt.Fprintln(w, readTestData("error-unknown.json"))
+	})
+
+	server := httptest.NewServer(handler)
+	defer server.Close()
+
+	client = New(token)
+	client.BaseURL = server.URL
+
+	release, call, err := client.Releases.Create(2, &Release{})
+	assert.NotNil(err)
+	assert.IsType(ErrorResponse{}, err)
+	assert.IsType(&Call{}, call)
+	assert.Nil(release)
+}
+
+func TestReleaseServiceChangeset(t *testing.T) {
+	var err error
+	assert := assert.New(t)
+
+	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal("GET", r.Method)
+		assert.Equal("/projects/2/releases/2/changeset.json", r.URL.Path)
+		checkHeaders(r, assert)
+
+		w.WriteHeader(200)
+		fmt.Fprintln(w, readTestData("release_changeset.json"))
+	})
+
+	server := httptest.NewServer(handler)
+	defer server.Close()
+
+	client = New(token)
+	client.BaseURL = server.URL
+
+	changeset, call, err := client.Releases.Changeset(2, 2)
+	assert.Nil(err)
+	assert.IsType(&Call{}, call)
+	assert.Equal("v1", *changeset.Previous)
+	assert.Equal(1, len(changeset.Commits))
+	assert.Equal(12, *changeset.PullRequests[0].Number)
+	assert.Equal("Gemfile.lock", *changeset.Files[0].Filename)
+}
+
+func TestReleaseServiceDeploys(t *testing.T) {
+	var err error
+	assert := assert.New(t)
+
+	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal("GET", r.Method)
+		assert.Equal("/projects/2/releases/2/deploys.json", r.URL.Path)
+		checkHeaders(r, assert)
+
+		w.WriteHeader(200)
+		fmt.Fprintln(w, readTestData("release_deploys.json"))
+	})
+
+	server := httptest.NewServer(handler)
+	defer server.Close()
+
+	client = New(token)
+	client.BaseURL = server.URL
+
+	deploys, call, err := client.Releases.Deploys(2, 2)
+	assert.Nil(err)
+	assert.IsType(&Call{}, call)
+	assert.Equal(1, len(deploys))
+	assert.Equal("v2", *deploys[0].Reference)
+}
+
+func TestReleaseServiceAutoDeployStages(t *testing.T) {
+	var err error
+	assert := assert.New(t)
+
+	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal("GET", r.Method)
+		assert.Equal("/stages.json", r.URL.Path)
+
+		w.WriteHeader(200)
+		fmt.Fprintln(w, readTestData("stages_release.json"))
+	})
+
+	server := httptest.NewServer(handler)
+	defer server.Close()
+
+	client = New(token)
+	client.BaseURL = server.URL
+
+	stages, call, err := client.Releases.AutoDeployStages(2)
+	assert.Nil(err)
+	assert.IsType(&Call{}, call)
+	assert.Equal(1, len(stages))
+	assert.Equal(1, *stages[0].ID)
+}
+
+func TestReleaseServiceAutoDeployStages_fail(t *testing.T) {
+	var err error
+	assert := assert.New(t)
+
+	client = New(token)
+	client.BaseURL = "^http://localhost"
+
+	stages, call, err := client.Releases.AutoDeployStages(2)
+	assert.NotNil(err)
+	assert.IsType(&Call{}, call)
+	assert.Nil(stages)
+}
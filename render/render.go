@@ -0,0 +1,345 @@
+// Package render prints samson models as tables, JSON, YAML, CSV or
+// through a text/template.
+package render
+
+import (
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"reflect"
+	"strings"
+	"text/tabwriter"
+	"text/template"
+	"time"
+)
+
+// Format is an output format
+type Format string
+
+// Formats
+const (
+	Table    Format = "table"
+	JSON     Format = "json"
+	YAML     Format = "yaml"
+	CSV      Format = "csv"
+	Template Format = "template"
+)
+
+// Formats lists all the supported formats
+var Formats = []Format{Table, JSON, YAML, CSV, Template}
+
+// ParseFormat parses a format name, an empty name is a table
+func ParseFormat(s string) (Format, error) {
+	if s == "" {
+		return Table, nil
+	}
+
+	for _, format := range Formats {
+		if string(format) == s {
+			return format, nil
+		}
+	}
+
+	return "", fmt.Errorf("unknown format %q", s)
+}
+
+// Options configures the output
+type Options struct {
+	Format Format
+	// Columns selects and orders the printed fields by json name,
+	// all scalar fields are printed when empty
+	Columns []string
+	// Template is executed for every item with the Template format
+	Template string
+}
+
+// Render writes a model or a slice of models to w
+func Render(w io.Writer, v interface{}, opts Options) error {
+	switch opts.Format {
+	case "", Table:
+		return renderTable(w, v, opts.Columns)
+	case JSON:
+		return renderJSON(w, v, opts.Columns)
+	case YAML:
+		return renderYAML(w, v, opts.Columns)
+	case CSV:
+		return renderCSV(w, v, opts.Columns)
+	case Template:
+		return renderTemplate(w, v, opts.Template)
+	}
+
+	return fmt.Errorf("unknown format %q", opts.Format)
+}
+
+// Columns returns the json names of the fields of a model or a slice of
+// models in struct order
+func Columns(v interface{}) ([]string, error) {
+	t, err := elemType(v)
+	if err != nil {
+		return nil, err
+	}
+
+	fields := structFields(t)
+	columns := make([]string, len(fields))
+	for i, f := range fields {
+		columns[i] = f.name
+	}
+
+	return columns, nil
+}
+
+// tableEscaper keeps multi line values like command scripts on their row
+var tableEscaper = strings.NewReplacer("\r", `\r`, "\n", `\n`, "\t", `\t`)
+
+func renderTable(w io.Writer, v interface{}, columns []string) error {
+	fields, rows, err := cells(v, columns)
+	if err != nil {
+		return err
+	}
+
+	tw := tabwriter.NewWriter(w, 0, 4, 2, ' ', 0)
+	headers := make([]string, len(fields))
+	for i, f := range fields {
+		headers[i] = strings.ToUpper(f.name)
+	}
+	fmt.Fprintln(tw, strings.Join(headers, "\t"))
+	for _, row := range rows {
+		escaped := make([]string, len(row))
+		for i, value := range row {
+			escaped[i] = tableEscaper.Replace(value)
+		}
+		fmt.Fprintln(tw, strings.Join(escaped, "\t"))
+	}
+
+	return tw.Flush()
+}
+
+func renderCSV(w io.Writer, v interface{}, columns []string) error {
+	fields, rows, err := cells(v, columns)
+	if err != nil {
+		return err
+	}
+
+	cw := csv.NewWriter(w)
+	headers := make([]string, len(fields))
+	for i, f := range fields {
+		headers[i] = f.name
+	}
+	cw.Write(headers)
+	for _, row := range rows {
+		cw.Write(row)
+	}
+	cw.Flush()
+
+	return cw.Error()
+}
+
+func renderJSON(w io.Writer, v interface{}, columns []string) error {
+	data, err := json.Marshal(v)
+	if err != nil {
+		return err
+	}
+
+	if len(columns) > 0 {
+		ordered, err := orderedValue(v, columns)
+		if err != nil {
+			return err
+		}
+		data, err = marshalOrdered(ordered)
+		if err != nil {
+			return err
+		}
+	}
+
+	var out bytes.Buffer
+	err = json.Indent(&out, data, "", "  ")
+	if err != nil {
+		return err
+	}
+
+	_, err = fmt.Fprintln(w, out.String())
+	return err
+}
+
+func renderTemplate(w io.Writer, v interface{}, text string) error {
+	tmpl, err := template.New("render").Parse(text)
+	if err != nil {
+		return err
+	}
+
+	items := reflect.ValueOf(v)
+	if items.Kind() != reflect.Slice && items.Kind() != reflect.Array {
+		items = reflect.ValueOf([]interface{}{v})
+	}
+
+	for i := 0; i < items.Len(); i++ {
+		err = tmpl.Execute(w, items.Index(i).Interface())
+		if err != nil {
+			return err
+		}
+		fmt.Fprintln(w)
+	}
+
+	return nil
+}
+
+// field is a printable model field
+type field struct {
+	name  string
+	index int
+}
+
+// elemType returns the struct type of a model or of a slice's items
+func elemType(v interface{}) (reflect.Type, error) {
+	if v == nil {
+		return nil, fmt.Errorf("cannot render nil")
+	}
+
+	t := reflect.TypeOf(v)
+	if t.Kind() == reflect.Slice || t.Kind() == reflect.Array {
+		t = t.Elem()
+	}
+	for t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+	if t.Kind() != reflect.Struct {
+		return nil, fmt.Errorf("cannot render %s", reflect.TypeOf(v))
+	}
+
+	return t, nil
+}
+
+// structFields returns the json named fields of a struct in struct order
+func structFields(t reflect.Type) []field {
+	var fields []field
+	for i := 0; i < t.NumField(); i++ {
+		f := t.Field(i)
+		name := strings.Split(f.Tag.Get("json"), ",")[0]
+		if name == "-" || f.PkgPath != "" {
+			continue
+		}
+		if name == "" {
+			name = f.Name
+		}
+
+		fields = append(fields, field{name: name, index: i})
+	}
+
+	return fields
+}
+
+// selectFields picks the requested columns, or every scalar one
+func selectFields(t reflect.Type, columns []string) ([]field, error) {
+	fields := structFields(t)
+	if len(columns) == 0 {
+		var scalars []field
+		for _, f := range fields {
+			if isScalar(t.Field(f.index).Type) {
+				scalars = append(scalars, f)
+			}
+		}
+		return scalars, nil
+	}
+
+	byName := map[string]field{}
+	for _, f := range fields {
+		byName[f.name] = f
+	}
+
+	selected := make([]field, len(columns))
+	for i, column := range columns {
+		f, ok := byName[column]
+		if !ok {
+			return nil, fmt.Errorf("unknown column %q for %s", column, t.Name())
+		}
+		selected[i] = f
+	}
+
+	return selected, nil
+}
+
+// cells flattens a model or slice of models into string rows
+func cells(v interface{}, columns []string) ([]field, [][]string, error) {
+	t, err := elemType(v)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	fields, err := selectFields(t, columns)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	items := reflect.ValueOf(v)
+	if items.Kind() != reflect.Slice && items.Kind() != reflect.Array {
+		items = reflect.Append(reflect.MakeSlice(reflect.SliceOf(items.Type()), 0, 1), items)
+	}
+
+	var rows [][]string
+	for i := 0; i < items.Len(); i++ {
+		item := reflect.Indirect(items.Index(i))
+		if !item.IsValid() {
+			continue
+		}
+
+		row := make([]string, len(fields))
+		for j, f := range fields {
+			row[j] = cell(item.Field(f.index))
+		}
+		rows = append(rows, row)
+	}
+
+	return fields, rows, nil
+}
+
+var timeType = reflect.TypeOf(time.Time{})
+
+func isScalar(t reflect.Type) bool {
+	if t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+	if t == timeType {
+		return true
+	}
+
+	switch t.Kind() {
+	case reflect.Struct, reflect.Map, reflect.Interface:
+		return false
+	case reflect.Slice, reflect.Array:
+		return isScalar(t.Elem()) && t.Elem() != timeType
+	}
+
+	return true
+}
+
+// cell formats a field value, nested structures are printed as JSON
+func cell(v reflect.Value) string {
+	if (v.Kind() == reflect.Ptr || v.Kind() == reflect.Slice || v.Kind() == reflect.Map || v.Kind() == reflect.Interface) && v.IsNil() {
+		return ""
+	}
+	if v.Kind() == reflect.Ptr {
+		v = v.Elem()
+	}
+
+	if v.Type() == timeType {
+		return v.Interface().(time.Time).Format(time.RFC3339)
+	}
+	if !isScalar(v.Type()) {
+		data, _ := json.Marshal(v.Interface())
+		return string(data)
+	}
+	if v.Kind() == reflect.Slice || v.Kind() == reflect.Array {
+		parts := make([]string, v.Len())
+		for i := range parts {
+			parts[i] = cell(v.Index(i))
+		}
+		return strings.Join(parts, ",")
+	}
+	if stringer, ok := v.Interface().(fmt.Stringer); ok {
+		return stringer.String()
+	}
+
+	return fmt.Sprint(v.Interface())
+}
@@ -0,0 +1,248 @@
+package render
+
+import (
+	"bytes"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	samson "github.com/tolgaakyuz/samson-go"
+)
+
+func projects() []*samson.Project {
+	created := time.Date(2018, 3, 26, 11, 52, 1, 0, time.UTC)
+
+	return []*samson.Project{
+		{
+			ID:            samson.Int(1),
+			Name:          samson.String("Example Project"),
+			Permalink:     samson.String("example-project"),
+			RepositoryURL: samson.String("git@github.com:samson-test-org/example-project.git"),
+			CreatedAt:     &created,
+		},
+		{
+			ID:                          samson.Int(2),
+			Name:                        samson.String("Kubernetes, \"quoted\""),
+			Permalink:                   samson.String("example-kubernetes"),
+			EnvironmentVariableGroupIds: []*int{samson.Int(1), samson.Int(2)},
+		},
+	}
+}
+
+func TestParseFormat(t *testing.T) {
+	assert := assert.New(t)
+
+	for _, format := range Formats {
+		parsed, err := ParseFormat(string(format))
+		assert.Nil(err)
+		assert.Equal(format, parsed)
+	}
+
+	format, err := ParseFormat("")
+	assert.Nil(err)
+	assert.Equal(Table, format)
+
+	_, err = ParseFormat("xml")
+	assert.EqualError(err, `unknown format "xml"`)
+}
+
+func TestColumns(t *testing.T) {
+	assert := assert.New(t)
+
+	columns, err := Columns([]*samson.Command{})
+	assert.Nil(err)
+	assert.Equal([]string{"id", "command", "project_id"}, columns)
+
+	columns, err = Columns(&samson.Environment{})
+	assert.Nil(err)
+	assert.Equal([]string{"id", "name", "permalink", "production"}, columns)
+
+	columns, err = Columns(projects())
+	assert.Nil(err)
+	assert.Equal("id", columns[0])
+	assert.Equal("name", columns[1])
+
+	_, err = Columns([]int{1})
+	assert.EqualError(err, "cannot render []int")
+
+	_, err = Columns(nil)
+	assert.NotNil(err)
+}
+
+func TestRender_table(t *testing.T) {
+	assert := assert.New(t)
+
+	var out bytes.Buffer
+	err := Render(&out, projects(), Options{Columns: []string{"id", "name", "environment_variable_group_ids", "created_at"}})
+	assert.Nil(err)
+	assert.Equal(``+
+		"ID  NAME                  ENVIRONMENT_VARIABLE_GROUP_IDS  CREATED_AT\n"+
+		"1   Example Project                                       2018-03-26T11:52:01Z\n"+
+		"2   Kubernetes, \"quoted\"  1,2                             \n", out.String())
+
+	out.Reset()
+	err = Render(&out, &samson.Command{ID: samson.Int(1), Command: samson.String("echo hello")}, Options{Format: Table})
+	assert.Nil(err)
+	assert.Equal("ID  COMMAND     PROJECT_ID\n1   echo hello  \n", out.String())
+
+	out.Reset()
+	err = Render(&out, []*samson.Command{
+		{ID: samson.Int(1), Command: samson.String("set -e\nmake build\tall")},
+		{ID: samson.Int(2), Command: samson.String("ls")},
+	}, Options{Format: Table})
+	assert.Nil(err)
+	assert.Equal(``+
+		"ID  COMMAND                  PROJECT_ID\n"+
+		"1   set -e\\nmake build\\tall  \n"+
+		"2   ls                       \n", out.String())
+
+	out.Reset()
+	err = Render(&out, &samson.Command{ID: samson.Int(1), Command: samson.String("set -e\nmake")}, Options{Format: CSV})
+	assert.Nil(err)
+	assert.Equal("id,command,project_id\n1,\"set -e\nmake\",\n", out.String())
+
+	out.Reset()
+	err = Render(&out, projects(), Options{Columns: []string{"id", "colour"}})
+	assert.EqualError(err, `unknown column "colour" for Project`)
+}
+
+func TestRender_tableNested(t *testing.T) {
+	assert := assert.New(t)
+
+	stage := &samson.Stage{
+		ID:         samson.Int(1),
+		Name:       samson.String("production"),
+		CommandIds: []*int{samson.Int(2), samson.Int(3)},
+		SlackWebhookAtrributes: []*samson.SlackWebhookAttribute{
+			{Channel: samson.String("deploys")},
+		},
+	}
+
+	var out bytes.Buffer
+	err := Render(&out, []*samson.Stage{stage}, Options{Columns: []string{"name", "command_ids", "slack_webhooks_attributes"}})
+	assert.Nil(err)
+	assert.Equal("NAME        COMMAND_IDS  SLACK_WEBHOOKS_ATTRIBUTES\nproduction  2,3          [{\"channel\":\"deploys\"}]\n", out.String())
+
+	out.Reset()
+	err = Render(&out, []*samson.Stage{stage}, Options{})
+	assert.Nil(err)
+	assert.NotContains(out.String(), "SLACK_WEBHOOKS_ATTRIBUTES")
+	assert.Contains(out.String(), "COMMAND_IDS")
+}
+
+func TestRender_json(t *testing.T) {
+	assert := assert.New(t)
+
+	var out bytes.Buffer
+	err := Render(&out, projects()[:1], Options{Format: JSON})
+	assert.Nil(err)
+	assert.Equal(`[
+  {
+    "id": 1,
+    "name": "Example Project",
+    "repository_url": "git@github.com:samson-test-org/example-project.git",
+    "permalink": "example-project",
+    "created_at": "2018-03-26T11:52:01Z"
+  }
+]
+`, out.String())
+
+	out.Reset()
+	err = Render(&out, projects(), Options{Format: JSON, Columns: []string{"permalink", "id", "description"}})
+	assert.Nil(err)
+	assert.Equal(`[
+  {
+    "permalink": "example-project",
+    "id": 1,
+    "description": null
+  },
+  {
+    "permalink": "example-kubernetes",
+    "id": 2,
+    "description": null
+  }
+]
+`, out.String())
+
+	err = Render(&out, projects(), Options{Format: JSON, Columns: []string{"colour"}})
+	assert.NotNil(err)
+}
+
+func TestRender_yaml(t *testing.T) {
+	assert := assert.New(t)
+
+	var out bytes.Buffer
+	err := Render(&out, projects(), Options{Format: YAML})
+	assert.Nil(err)
+	assert.Equal(`- id: 1
+  name: Example Project
+  repository_url: git@github.com:samson-test-org/example-project.git
+  permalink: example-project
+  created_at: "2018-03-26T11:52:01Z"
+- id: 2
+  name: Kubernetes, "quoted"
+  permalink: example-kubernetes
+  environment_variable_group_ids:
+  - 1
+  - 2
+`, out.String())
+
+	out.Reset()
+	err = Render(&out, &samson.Environment{ID: samson.Int(1), Name: samson.String("Production"), Production: samson.Bool(true)}, Options{Format: YAML, Columns: []string{"name", "production"}})
+	assert.Nil(err)
+	assert.Equal("name: Production\nproduction: true\n", out.String())
+
+	err = Render(&out, projects(), Options{Format: YAML, Columns: []string{"colour"}})
+	assert.NotNil(err)
+}
+
+func TestRender_csv(t *testing.T) {
+	assert := assert.New(t)
+
+	var out bytes.Buffer
+	err := Render(&out, projects(), Options{Format: CSV, Columns: []string{"id", "name", "environment_variable_group_ids"}})
+	assert.Nil(err)
+	assert.Equal("id,name,environment_variable_group_ids\n1,Example Project,\n2,\"Kubernetes, \"\"quoted\"\"\",\"1,2\"\n", out.String())
+
+	err = Render(&out, "projects", Options{Format: CSV})
+	assert.NotNil(err)
+}
+
+func TestRender_template(t *testing.T) {
+	assert := assert.New(t)
+
+	var out bytes.Buffer
+	err := Render(&out, projects(), Options{Format: Template, Template: "{{.ID}} {{.Permalink}}"})
+	assert.Nil(err)
+	assert.Equal("1 example-project\n2 example-kubernetes\n", out.String())
+
+	out.Reset()
+	err = Render(&out, projects()[0], Options{Format: Template, Template: "{{.Name}}"})
+	assert.Nil(err)
+	assert.Equal("Example Project\n", out.String())
+
+	err = Render(&out, projects(), Options{Format: Template, Template: "{{.Name"})
+	assert.NotNil(err)
+
+	err = Render(&out, projects(), Options{Format: Template, Template: "{{.Colour}}"})
+	assert.NotNil(err)
+}
+
+func TestRender_fail(t *testing.T) {
+	assert := assert.New(t)
+
+	var out bytes.Buffer
+	err := Render(&out, projects(), Options{Format: "xml"})
+	assert.EqualError(err, `unknown format "xml"`)
+}
+
+func ExampleRender() {
+	client := samson.New("token")
+	projects, _, _ := client.Projects.List()
+
+	Render(os.Stdout, projects, Options{
+		Format:  Table,
+		Columns: []string{"id", "name", "permalink"},
+	})
+}
@@ -0,0 +1,172 @@
+package render
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+
+	yaml "gopkg.in/yaml.v2"
+)
+
+func renderYAML(w io.Writer, v interface{}, columns []string) error {
+	ordered, err := orderedValue(v, columns)
+	if err != nil {
+		return err
+	}
+
+	data, err := yaml.Marshal(ordered)
+	if err != nil {
+		return err
+	}
+
+	_, err = w.Write(data)
+	return err
+}
+
+// orderedValue converts v through its json form into maps keeping the
+// struct field order, optionally keeping only the given keys of objects
+// in v or in the top level slice
+func orderedValue(v interface{}, columns []string) (interface{}, error) {
+	if len(columns) > 0 {
+		t, err := elemType(v)
+		if err != nil {
+			return nil, err
+		}
+		_, err = selectFields(t, columns)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	data, err := json.Marshal(v)
+	if err != nil {
+		return nil, err
+	}
+
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.UseNumber()
+	ordered, err := decodeOrdered(decoder)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(columns) > 0 {
+		switch value := ordered.(type) {
+		case yaml.MapSlice:
+			ordered = pick(value, columns)
+		case []interface{}:
+			for i, item := range value {
+				if m, ok := item.(yaml.MapSlice); ok {
+					value[i] = pick(m, columns)
+				}
+			}
+		}
+	}
+
+	return ordered, nil
+}
+
+// decodeOrdered reads the next json value, objects become yaml.MapSlice
+func decodeOrdered(decoder *json.Decoder) (interface{}, error) {
+	token, err := decoder.Token()
+	if err != nil {
+		return nil, err
+	}
+
+	switch token {
+	case json.Delim('{'):
+		m := yaml.MapSlice{}
+		for decoder.More() {
+			key, err := decoder.Token()
+			if err != nil {
+				return nil, err
+			}
+			value, err := decodeOrdered(decoder)
+			if err != nil {
+				return nil, err
+			}
+			m = append(m, yaml.MapItem{Key: key, Value: value})
+		}
+		_, err = decoder.Token()
+		return m, err
+	case json.Delim('['):
+		list := []interface{}{}
+		for decoder.More() {
+			value, err := decodeOrdered(decoder)
+			if err != nil {
+				return nil, err
+			}
+			list = append(list, value)
+		}
+		_, err = decoder.Token()
+		return list, err
+	}
+
+	if number, ok := token.(json.Number); ok {
+		if i, err := number.Int64(); err == nil {
+			return i, nil
+		}
+		return number.Float64()
+	}
+
+	return token, nil
+}
+
+// pick keeps the given keys in the given order, absent keys are null
+func pick(m yaml.MapSlice, keys []string) yaml.MapSlice {
+	picked := make(yaml.MapSlice, len(keys))
+	for i, key := range keys {
+		picked[i] = yaml.MapItem{Key: key}
+		for _, item := range m {
+			if item.Key == key {
+				picked[i].Value = item.Value
+			}
+		}
+	}
+
+	return picked
+}
+
+// marshalOrdered encodes a value from orderedValue as json keeping the
+// key order
+func marshalOrdered(v interface{}) ([]byte, error) {
+	var buf bytes.Buffer
+	switch value := v.(type) {
+	case yaml.MapSlice:
+		buf.WriteByte('{')
+		for i, item := range value {
+			if i > 0 {
+				buf.WriteByte(',')
+			}
+			key, err := json.Marshal(item.Key)
+			if err != nil {
+				return nil, err
+			}
+			data, err := marshalOrdered(item.Value)
+			if err != nil {
+				return nil, err
+			}
+			buf.Write(key)
+			buf.WriteByte(':')
+			buf.Write(data)
+		}
+		buf.WriteByte('}')
+	case []interface{}:
+		buf.WriteByte('[')
+		for i, item := range value {
+			if i > 0 {
+				buf.WriteByte(',')
+			}
+			data, err := marshalOrdered(item)
+			if err != nil {
+				return nil, err
+			}
+			buf.Write(data)
+		}
+		buf.WriteByte(']')
+	default:
+		return json.Marshal(value)
+	}
+
+	return buf.Bytes(), nil
+}
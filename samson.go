@@ -14,10 +14,27 @@ type Samson struct {
 	Headers     map[string]string
 	BaseURL     string
 
-	Projects     *ProjectService
-	Stages       *StageService
-	Commands     *CommandService
-	Environments *EnvironmentService
+	// Strict reports response fields the models don't know about and
+	// conflicting model tags, through OnWarnings or as a StrictError
+	Strict     bool
+	OnWarnings func(warnings []string)
+
+	Projects                  *ProjectService
+	Stages                    *StageService
+	Commands                  *CommandService
+	Environments              *EnvironmentService
+	Releases                  *ReleaseService
+	DeployGroups              *DeployGroupService
+	Locks                     *LockService
+	Users                     *UserService
+	AccessTokens              *AccessTokenService
+	Secrets                   *SecretService
+	EnvironmentVariableGroups *EnvironmentVariableGroupService
+	OutboundWebhooks          *OutboundWebhookService
+	Webhooks                  *WebhookService
+	Kubernetes                *KubernetesService
+	Audits                    *AuditService
+	Deploys                   *DeployService
 }
 
 type service struct {
@@ -41,6 +58,18 @@ func New(token string) *Samson {
 	s.Stages = &StageService{s: s}
 	s.Commands = &CommandService{s: s}
 	s.Environments = &EnvironmentService{s: s}
+	s.Releases = &ReleaseService{s: s}
+	s.DeployGroups = &DeployGroupService{s: s}
+	s.Locks = &LockService{s: s}
+	s.Users = &UserService{s: s}
+	s.AccessTokens = &AccessTokenService{s: s}
+	s.Secrets = &SecretService{s: s}
+	s.EnvironmentVariableGroups = &EnvironmentVariableGroupService{s: s}
+	s.OutboundWebhooks = &OutboundWebhookService{s: s}
+	s.Webhooks = &WebhookService{s: s}
+	s.Kubernetes = &KubernetesService{s: s}
+	s.Audits = &AuditService{s: s}
+	s.Deploys = &DeployService{s: s}
 
 	return s
 }
@@ -80,6 +109,8 @@ func (s *Samson) NewCall(method, path string, queryParams, headers map[string]st
 		queryParams: query,
 		headers:     headers,
 		body:        body,
+		strict:      s.Strict,
+		onWarnings:  s.OnWarnings,
 	}
 
 	err = call.prepareRequest()
@@ -97,3 +128,7 @@ func String(s string) *string {
 func Int(i int) *int {
 	return &i
 }
+
+func Bool(b bool) *bool {
+	return &b
+}
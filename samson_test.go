@@ -87,6 +87,18 @@ func TestSamsonNew(t *testing.T) {
 	assert.IsType(Samson{}, *client)
 	assert.IsType(ProjectService{}, *client.Projects)
 	assert.IsType(StageService{}, *client.Stages)
+	assert.IsType(ReleaseService{}, *client.Releases)
+	assert.IsType(DeployGroupService{}, *client.DeployGroups)
+	assert.IsType(LockService{}, *client.Locks)
+	assert.IsType(UserService{}, *client.Users)
+	assert.IsType(AccessTokenService{}, *client.AccessTokens)
+	assert.IsType(SecretService{}, *client.Secrets)
+	assert.IsType(EnvironmentVariableGroupService{}, *client.EnvironmentVariableGroups)
+	assert.IsType(OutboundWebhookService{}, *client.OutboundWebhooks)
+	assert.IsType(WebhookService{}, *client.Webhooks)
+	assert.IsType(KubernetesService{}, *client.Kubernetes)
+	assert.IsType(AuditService{}, *client.Audits)
+	assert.IsType(DeployService{}, *client.Deploys)
 	assert.Equal("http://localhost:9080", client.BaseURL)
 	assert.Equal(token, client.token)
 	assert.Equal(fmt.Sprintf("Bearer %s", token), client.Headers["Authorization"])
@@ -175,3 +187,10 @@ func TestInt(t *testing.T) {
 	i := 1
 	assert.Equal(i, *Int(i))
 }
+
+func TestBool(t *testing.T) {
+	assert := assert.New(t)
+
+	assert.Equal(true, *Bool(true))
+	assert.Equal(false, *Bool(false))
+}
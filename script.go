@@ -0,0 +1,136 @@
+package samson
+
+import (
+	"bytes"
+	"fmt"
+	"strings"
+)
+
+// Problems of the commands of a stage script
+const (
+	ScriptCommandMissing = "missing"
+	ScriptCommandForeign = "foreign project"
+)
+
+// Script is the deploy script of a stage, the commands of its CommandIds
+// run in order
+type Script struct {
+	Stage    *Stage
+	Commands []*ScriptCommand
+}
+
+// ScriptCommand is a command of a stage script
+// Command is nil for missing commands, Problem is empty for global
+// commands and commands of the stage's project
+type ScriptCommand struct {
+	ID      int
+	Command *Command
+	Problem string
+}
+
+// Problems returns the missing and foreign project commands of the script
+func (script *Script) Problems() []*ScriptCommand {
+	var problems []*ScriptCommand
+	for _, command := range script.Commands {
+		if command.Problem != "" {
+			problems = append(problems, command)
+		}
+	}
+
+	return problems
+}
+
+// String returns the script with a comment before every command naming it
+// and its project, missing commands are only a comment naming the problem
+func (script *Script) String() string {
+	var buf bytes.Buffer
+
+	fmt.Fprintf(&buf, "# stage %s (%d)\n", stringValue(script.Stage.Name), intValue(script.Stage.ID))
+	for _, command := range script.Commands {
+		buf.WriteString("\n")
+
+		if command.Command == nil {
+			fmt.Fprintf(&buf, "# command %d: %s\n", command.ID, command.Problem)
+			continue
+		}
+
+		scope := "global"
+		if !command.Command.IsGlobal() {
+			scope = fmt.Sprintf("project %d", *command.Command.ProjectID)
+		}
+		if command.Problem != "" {
+			fmt.Fprintf(&buf, "# command %d (%s): %s\n", command.ID, scope, command.Problem)
+		} else {
+			fmt.Fprintf(&buf, "# command %d (%s)\n", command.ID, scope)
+		}
+
+		text := strings.TrimRight(stringValue(command.Command.Command), "\n")
+		buf.WriteString(strings.Replace(text, "\r\n", "\n", -1) + "\n")
+	}
+
+	return buf.String()
+}
+
+// RenderScript resolves the commands of a stage in order, fetching all
+// commands in one request
+// Commands which don't exist or belong to another project are reported
+// through ScriptCommand.Problem instead of an error
+func (service *StageService) RenderScript(stageID int) (*Script, *Call, error) {
+	stage, call, err := service.Get(stageID)
+	if err != nil {
+		return nil, call, err
+	}
+
+	script := &Script{Stage: stage, Commands: []*ScriptCommand{}}
+	if len(stage.CommandIds) == 0 {
+		return script, call, nil
+	}
+
+	commands, call, err := service.s.Commands.List()
+	if err != nil {
+		return nil, call, err
+	}
+	byID := map[int]*Command{}
+	for _, command := range commands {
+		if command.ID != nil {
+			byID[*command.ID] = command
+		}
+	}
+
+	for _, commandID := range stage.CommandIds {
+		if commandID == nil {
+			continue
+		}
+
+		scriptCommand := &ScriptCommand{ID: *commandID}
+		command, ok := byID[*commandID]
+		if !ok {
+			scriptCommand.Problem = ScriptCommandMissing
+		} else {
+			scriptCommand.Command = command
+			if !command.IsGlobal() && (stage.ProjectID == nil || *command.ProjectID != *stage.ProjectID) {
+				scriptCommand.Problem = ScriptCommandForeign
+			}
+		}
+
+		script.Commands = append(script.Commands, scriptCommand)
+	}
+
+	return script, call, nil
+}
+
+func stringValue(s *string) string {
+	if s == nil {
+		return ""
+	}
+
+	return *s
+}
+
+func intValue(i *int) int {
+	if i == nil {
+		return 0
+	}
+
+	return *i
+}
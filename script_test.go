@@ -0,0 +1,128 @@
+package samson
+
+import (
+	"fmt"
+	"log"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func ExampleStageService_RenderScript() {
+	client := New("token")
+
+	script, _, err := client.Stages.RenderScript(1)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	for _, problem := range script.Problems() {
+		log.Printf("command %d: %s", problem.ID, problem.Problem)
+	}
+
+	fmt.Print(script)
+}
+
+func TestStageServiceRenderScript(t *testing.T) {
+	var err error
+	assert := assert.New(t)
+
+	var paths []string
+	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal("GET", r.Method)
+		checkHeaders(r, assert)
+		paths = append(paths, r.URL.Path)
+
+		switch r.URL.Path {
+		case "/stages/1.json":
+			fmt.Fprintln(w, `{"id": 1, "name": "Production", "project_id": 7, "command_ids": ["3", "1", "9", "4"]}`)
+		case "/commands.json":
+			fmt.Fprintln(w, `{"commands": [
+				{"id": 1, "command": "./notify.sh\n"},
+				{"id": 3, "command": "bundle install\r\nbundle exec cap deploy", "project_id": "7"},
+				{"id": 4, "command": "make release", "project_id": 8},
+				{"id": 5, "command": "unused"}
+			]}`)
+		case "/stages/2.json":
+			fmt.Fprintln(w, `{"id": 2, "name": "Empty", "command_ids": []}`)
+		default:
+			w.WriteHeader(404)
+			fmt.Fprintln(w, `{"message": "Not Found"}`)
+		}
+	})
+
+	server := httptest.NewServer(handler)
+	defer server.Close()
+
+	client = New(token)
+	client.BaseURL = server.URL
+
+	script, call, err := client.Stages.RenderScript(1)
+	assert.Nil(err)
+	assert.IsType(&Call{}, call)
+	assert.Equal([]string{"/stages/1.json", "/commands.json"}, paths)
+
+	assert.Len(script.Commands, 4)
+	assert.Equal("", script.Commands[0].Problem)
+	assert.Equal("", script.Commands[1].Problem)
+	assert.Equal(ScriptCommandMissing, script.Commands[2].Problem)
+	assert.Nil(script.Commands[2].Command)
+	assert.Equal(ScriptCommandForeign, script.Commands[3].Problem)
+
+	problems := script.Problems()
+	assert.Len(problems, 2)
+	assert.Equal(9, problems[0].ID)
+	assert.Equal(4, problems[1].ID)
+
+	assert.Equal(`# stage Production (1)
+
+# command 3 (project 7)
+bundle install
+bundle exec cap deploy
+
+# command 1 (global)
+./notify.sh
+
+# command 9: missing
+
+# command 4 (project 8): foreign project
+make release
+`, script.String())
+
+	paths = nil
+	script, _, err = client.Stages.RenderScript(2)
+	assert.Nil(err)
+	assert.Empty(script.Commands)
+	assert.Equal([]string{"/stages/2.json"}, paths)
+}
+
+func TestStageServiceRenderScript_fail(t *testing.T) {
+	var err error
+	assert := assert.New(t)
+
+	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/stages/1.json" {
+			fmt.Fprintln(w, `{"id": 1, "project_id": 7, "command_ids": [2]}`)
+			return
+		}
+		w.WriteHeader(500)
+		fmt.Fprintln(w, `{"message": "Internal Server Error"}`)
+	})
+
+	server := httptest.NewServer(handler)
+	defer server.Close()
+
+	client = New(token)
+	client.BaseURL = server.URL
+
+	script, call, err := client.Stages.RenderScript(1)
+	assert.EqualError(err, "Internal Server Error")
+	assert.IsType(&Call{}, call)
+	assert.Nil(script)
+
+	script, _, err = client.Stages.RenderScript(2)
+	assert.NotNil(err)
+	assert.Nil(script)
+}
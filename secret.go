@@ -0,0 +1,183 @@
+package samson
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+)
+
+// SecretService service
+type SecretService service
+
+// SecretGlobal scopes a secret key to all environments, projects or deploy groups
+const SecretGlobal = "global"
+
+// secretReferencePrefix marks environment variable values resolved from secrets
+const secretReferencePrefix = "secret://"
+
+// SecretKey is the scoped key of a secret, formatted by Samson as
+// environment/project/deploy_group/key using permalinks or "global"
+type SecretKey struct {
+	Environment string
+	Project     string
+	DeployGroup string
+	Key         string
+}
+
+// NewSecretKey returns a key available to all environments, projects and deploy groups
+func NewSecretKey(key string) SecretKey {
+	return SecretKey{
+		Environment: SecretGlobal,
+		Project:     SecretGlobal,
+		DeployGroup: SecretGlobal,
+		Key:         key,
+	}
+}
+
+// ParseSecretKey parses a scoped secret key, the key itself may contain /
+// like production/example/pod1/db/password
+func ParseSecretKey(s string) (SecretKey, error) {
+	parts := strings.SplitN(s, "/", 4)
+	if len(parts) != 4 {
+		return SecretKey{}, fmt.Errorf("secret key %q must have 4 parts separated by /", s)
+	}
+
+	for _, part := range parts {
+		if part == "" {
+			return SecretKey{}, fmt.Errorf("secret key %q has an empty part", s)
+		}
+	}
+
+	return SecretKey{
+		Environment: parts[0],
+		Project:     parts[1],
+		DeployGroup: parts[2],
+		Key:         parts[3],
+	}, nil
+}
+
+// String returns the key in Samson's scoped key format
+func (k SecretKey) String() string {
+	return strings.Join([]string{k.Environment, k.Project, k.DeployGroup, k.Key}, "/")
+}
+
+// Reference returns the value used to refer to the secret from an environment variable
+func (k SecretKey) Reference() string {
+	return secretReferencePrefix + k.Key
+}
+
+// ParseSecretReference returns the key referenced by an environment variable value
+func ParseSecretReference(value string) (string, bool) {
+	if !strings.HasPrefix(value, secretReferencePrefix) {
+		return "", false
+	}
+
+	return strings.TrimPrefix(value, secretReferencePrefix), true
+}
+
+// Secret model
+// Value is only returned for visible secrets
+type Secret struct {
+	ID         *string    `json:"id,omitempty"`
+	Value      *string    `json:"value,omitempty"`
+	Visible    *bool      `json:"visible,omitempty"`
+	Deprecated *bool      `json:"deprecated,omitempty"`
+	Comment    *string    `json:"comment,omitempty"`
+	CreatorID  *int       `json:"creator_id,omitempty"`
+	UpdaterID  *int       `json:"updater_id,omitempty"`
+	CreatedAt  *time.Time `json:"created_at,omitempty"`
+	UpdatedAt  *time.Time `json:"updated_at,omitempty"`
+}
+
+// Key returns the parsed key of the secret
+func (s *Secret) Key() (SecretKey, error) {
+	if s.ID == nil {
+		return SecretKey{}, fmt.Errorf("secret has no id")
+	}
+
+	return ParseSecretKey(*s.ID)
+}
+
+// List returns the keys of all secrets
+func (service *SecretService) List() ([]SecretKey, *Call, error) {
+	path := "/secrets.json"
+	method := "GET"
+
+	call, err := service.s.NewCall(method, path, nil, nil, nil)
+	if err != nil {
+		return nil, call, err
+	}
+
+	type response struct {
+		Keys []string `json:"keys,omitempty"`
+	}
+	var res response
+	err = call.Do(&res)
+	if err != nil {
+		return nil, call, err
+	}
+
+	keys := make([]SecretKey, len(res.Keys))
+	for i, key := range res.Keys {
+		keys[i], err = ParseSecretKey(key)
+		if err != nil {
+			return nil, call, err
+		}
+	}
+
+	return keys, call, nil
+}
+
+// Get returns the metadata of a single secret
+func (service *SecretService) Get(key SecretKey) (*Secret, *Call, error) {
+	path := fmt.Sprintf("/secrets/%s.json", key)
+	method := "GET"
+
+	call, err := service.s.NewCall(method, path, nil, nil, nil)
+	if err != nil {
+		return nil, call, err
+	}
+
+	var secret Secret
+	err = call.Do(&secret)
+	if err != nil {
+		return nil, call, err
+	}
+
+	return &secret, call, nil
+}
+
+// Write creates or overwrites the secret with the given key
+func (service *SecretService) Write(key SecretKey, secret *Secret) (*Secret, *Call, error) {
+	bytesArray, _ := json.Marshal(secret)
+
+	path := fmt.Sprintf("/secrets/%s.json", key)
+	method := "PUT"
+
+	call, err := service.s.NewCall(method, path, nil, nil, bytes.NewReader(bytesArray))
+	if err != nil {
+		return nil, call, err
+	}
+
+	err = call.Do(&secret)
+	if err != nil {
+		return nil, call, err
+	}
+
+	return secret, call, nil
+}
+
+// Delete deletes a sinlge secret
+func (service *SecretService) Delete(key SecretKey) (*Call, error) {
+	path := fmt.Sprintf("/secrets/%s.json", key)
+	method := "DELETE"
+
+	call, err := service.s.NewCall(method, path, nil, nil, nil)
+	if err != nil {
+		return call, err
+	}
+
+	return call, call.Do(nil)
+}
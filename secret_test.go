@@ -0,0 +1,247 @@
+package samson
+
+import (
+	"encoding/json"
+	"fmt"
+	"log"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func ExampleSecretService_Write() {
+	client := New("token")
+
+	key := SecretKey{
+		Environment: "production",
+		Project:     "example-kubernetes",
+		DeployGroup: SecretGlobal,
+		Key:         "database_password",
+	}
+
+	_, _, err := client.Secrets.Write(key, &Secret{Value: String("hunter2")})
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	fmt.Println(key.Reference())
+}
+
+func TestParseSecretKey(t *testing.T) {
+	assert := assert.New(t)
+
+	key, err := ParseSecretKey("production/example-kubernetes/pod1/database_password")
+	assert.Nil(err)
+	assert.Equal("production", key.Environment)
+	assert.Equal("example-kubernetes", key.Project)
+	assert.Equal("pod1", key.DeployGroup)
+	assert.Equal("database_password", key.Key)
+	assert.Equal("production/example-kubernetes/pod1/database_password", key.String())
+
+	key, err = ParseSecretKey("production/foo/pod1/db/password")
+	assert.Nil(err)
+	assert.Equal("pod1", key.DeployGroup)
+	assert.Equal("db/password", key.Key)
+	assert.Equal("production/foo/pod1/db/password", key.String())
+
+	_, err = ParseSecretKey("production/database_password")
+	assert.NotNil(err)
+
+	_, err = ParseSecretKey("production/foo/pod1/")
+	assert.NotNil(err)
+
+	_, err = ParseSecretKey("production//pod1/database_password")
+	assert.NotNil(err)
+}
+
+func TestNewSecretKey(t *testing.T) {
+	assert := assert.New(t)
+
+	key := NewSecretKey("bugsnag_api_key")
+	assert.Equal("global/global/global/bugsnag_api_key", key.String())
+	assert.Equal("secret://bugsnag_api_key", key.Reference())
+}
+
+func TestParseSecretReference(t *testing.T) {
+	assert := assert.New(t)
+
+	key, ok := ParseSecretReference("secret://database_password")
+	assert.True(ok)
+	assert.Equal("database_password", key)
+
+	_, ok = ParseSecretReference("plain value")
+	assert.False(ok)
+}
+
+func TestSecretServiceList(t *testing.T) {
+	var err error
+	assert := assert.New(t)
+
+	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal("GET", r.Method)
+		assert.Equal("/secrets.json", r.URL.Path)
+		checkHeaders(r, assert)
+
+		w.WriteHeader(200)
+		fmt.Fprintln(w, readTestData("secrets.json"))
+	})
+
+	server := httptest.NewServer(handler)
+	defer server.Close()
+
+	client = New(token)
+	client.BaseURL = server.URL
+
+	keys, call, err := client.Secrets.List()
+	assert.Nil(err)
+	assert.IsType(&Call{}, call)
+	assert.Equal(4, len(keys))
+	assert.Equal(NewSecretKey("bugsnag_api_key"), keys[0])
+	assert.Equal("pod1", keys[1].DeployGroup)
+	assert.Equal("db/password", keys[3].Key)
+}
+
+func TestSecretServiceList_fail(t *testing.T) {
+	var err error
+	assert := assert.New(t)
+
+	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(200)
+		fmt.Fprintln(w, `{"keys": ["malformed"]}`)
+	})
+
+	server := httptest.NewServer(handler)
+	defer server.Close()
+
+	client = New(token)
+	client.BaseURL = server.URL
+
+	keys, call, err := client.Secrets.List()
+	assert.NotNil(err)
+	assert.IsType(&Call{}, call)
+	assert.Nil(keys)
+}
+
+func TestSecretServiceGet(t *testing.T) {
+	var err error
+	assert := assert.New(t)
+
+	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal("GET", r.Method)
+		assert.Equal("/secrets/production/example-kubernetes/pod1/database_password.json", r.URL.Path)
+		checkHeaders(r, assert)
+
+		w.WriteHeader(200)
+		fmt.Fprintln(w, readTestData("secret.json"))
+	})
+
+	server := httptest.NewServer(handler)
+	defer server.Close()
+
+	client = New(token)
+	client.BaseURL = server.URL
+
+	key, _ := ParseSecretKey("production/example-kubernetes/pod1/database_password")
+
+	secret, call, err := client.Secrets.Get(key)
+	assert.Nil(err)
+	assert.IsType(&Call{}, call)
+	assert.Nil(secret.Value)
+	assert.Equal(2, *secret.UpdaterID)
+
+	secretKey, err := secret.Key()
+	assert.Nil(err)
+	assert.Equal(key, secretKey)
+}
+
+func TestSecretServiceGet_fail(t *testing.T) {
+	var err error
+	assert := assert.New(t)
+
+	client = New(token)
+	client.BaseURL = "^http://localhost"
+
+	secret, call, err := client.Secrets.Get(NewSecretKey("database_password"))
+	assert.NotNil(err)
+	assert.IsType(&Call{}, call)
+	assert.Nil(secret)
+
+	_, err = (&Secret{}).Key()
+	assert.NotNil(err)
+}
+
+func TestSecretServiceWrite(t *testing.T) {
+	var err error
+	assert := assert.New(t)
+
+	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal("PUT", r.Method)
+		assert.Equal("/secrets/production/example-kubernetes/pod1/database_password.json", r.URL.Path)
+		checkHeaders(r, assert)
+
+		var payload map[string]interface{}
+		err := json.NewDecoder(r.Body).Decode(&payload)
+		assert.Nil(err)
+		assert.Equal("hunter2", payload["value"])
+		assert.Equal("rotated after incident #42", payload["comment"])
+
+		w.WriteHeader(200)
+		fmt.Fprintln(w, readTestData("secret.json"))
+	})
+
+	server := httptest.NewServer(handler)
+	defer server.Close()
+
+	client = New(token)
+	client.BaseURL = server.URL
+
+	key, _ := ParseSecretKey("production/example-kubernetes/pod1/database_password")
+	secret := &Secret{
+		Value:   String("hunter2"),
+		Comment: String("rotated after incident #42"),
+	}
+
+	secret, call, err := client.Secrets.Write(key, secret)
+	assert.Nil(err)
+	assert.IsType(&Call{}, call)
+	assert.Equal(key.String(), *secret.ID)
+}
+
+func TestSecretServiceWrite_fail(t *testing.T) {
+	var err error
+	assert := assert.New(t)
+
+	client = New(token)
+	client.BaseURL = "^http://localhost"
+
+	secret, call, err := client.Secrets.Write(NewSecretKey("database_password"), &Secret{})
+	assert.NotNil(err)
+	assert.IsType(&Call{}, call)
+	assert.Nil(secret)
+}
+
+func TestSecretServiceDelete(t *testing.T) {
+	var err error
+	assert := assert.New(t)
+
+	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal("DELETE", r.Method)
+		assert.Equal("/secrets/global/global/global/database_password.json", r.URL.Path)
+		checkHeaders(r, assert)
+
+		w.WriteHeader(200)
+		fmt.Fprintln(w, "")
+	})
+
+	server := httptest.NewServer(handler)
+	defer server.Close()
+
+	client = New(token)
+	client.BaseURL = server.URL
+
+	call, err := client.Secrets.Delete(NewSecretKey("database_password"))
+	assert.Nil(err)
+	assert.IsType(&Call{}, call)
+}
@@ -19,7 +19,7 @@ type Stage struct {
 	NotifyEmailAddess                      *string                  `json:"notify_email_address,omitempty"`
 	Order                                  *int                     `json:"order,omitempty"`
 	Command                                *string                  `json:"command,omitempty"`
-	CommandIds                             []*string                `json:"command_ids,omitempty"`
+	CommandIds                             []*int                   `json:"command_ids,omitempty"`
 	Confirm                                *bool                    `json:"confirm,omitempty"`
 	DatadogTags                            *string                  `json:"datadog_tags,omitempty"`
 	DatadogMonitorIds                      *string                  `json:"datadog_monitor_ids,omitempty"`
@@ -33,12 +33,12 @@ type Stage struct {
 	StaticEmailsOnAutomatedDeployFailure   *string                  `json:"static_emails_on_automated_deploy_failure,omitempty"`
 	JenkinsJobNames                        *string                  `json:"jenkins_job_names,omitempty"`
 	NextStageIds                           []*int                   `json:"next_stage_ids,omitempty"`
+	DeployGroupIds                         []*int                   `json:"deploy_group_ids,omitempty"`
 	NoCodeDeployed                         *bool                    `json:"no_code_deployed,omitempty"`
 	DockerBinaryPluginEnabled              *bool                    `json:"docker_binary_plugin_enabled,omitempty"`
 	IsTemplate                             *bool                    `json:"is_template,omitempty"`
-	TemplateStageID                        *int                     `json:"template_stage_id"`
+	TemplateStageID                        *int                     `json:"template_stage_id,omitempty"`
 	NotifyAirbrake                         *bool                    `json:"notify_airbrake,omitempty"`
-	TamplateStageID                        *int                     `json:"template_stage_id,omitempty"`
 	JenkinsEmailCommitters                 *bool                    `json:"jenkins_email_committers,omitempty"`
 	Kubernetes                             *bool                    `json:"kubernetes,omitempty"`
 	RunInParallel                          *bool                    `json:"run_in_parallel,omitempty"`
@@ -53,9 +53,49 @@ type Stage struct {
 	CreatedAt                              *time.Time               `json:"created_at,omitempty"`
 	UpdatedAt                              *time.Time               `json:"updated_at,omitempty"`
 	DeleteAt                               *time.Time               `json:"deleted_at,omitempty"`
+
+	// Deprecated: use TemplateStageID. This misspelled field shared its json
+	// name, so neither was decoded. It is a decoded copy of TemplateStageID
+	// now and never encoded.
+	TamplateStageID *int `json:"-"`
+}
+
+// UnmarshalJSON decodes a stage, accepting string ids
+func (s *Stage) UnmarshalJSON(data []byte) error {
+	type stage Stage
+	aux := struct {
+		*stage
+		ProjectID       json.RawMessage `json:"project_id,omitempty"`
+		CommandIds      json.RawMessage `json:"command_ids,omitempty"`
+		NextStageIds    json.RawMessage `json:"next_stage_ids,omitempty"`
+		DeployGroupIds  json.RawMessage `json:"deploy_group_ids,omitempty"`
+		TemplateStageID json.RawMessage `json:"template_stage_id,omitempty"`
+	}{stage: (*stage)(s)}
+
+	err := json.Unmarshal(data, &aux)
+	if err != nil {
+		return err
+	}
+
+	for _, err := range []error{
+		decodeFlexInt(aux.ProjectID, &s.ProjectID),
+		decodeFlexInts(aux.CommandIds, &s.CommandIds),
+		decodeFlexInts(aux.NextStageIds, &s.NextStageIds),
+		decodeFlexInts(aux.DeployGroupIds, &s.DeployGroupIds),
+		decodeFlexInt(aux.TemplateStageID, &s.TemplateStageID),
+	} {
+		if err != nil {
+			return err
+		}
+	}
+	s.TamplateStageID = s.TemplateStageID
+
+	return nil
 }
 
+// SlackWebhookAttribute model for stage slack notifications
 type SlackWebhookAttribute struct {
+	ID            *int    `json:"id,omitempty"`
 	WebhookURL    *string `json:"webhook_url,omitempty"`
 	Channel       *string `json:"channel,omitempty"`
 	BuddyBox      *bool   `json:"buddy_box,omitempty"`
@@ -63,6 +103,7 @@ type SlackWebhookAttribute struct {
 	BeforeDeploy  *bool   `json:"before_deploy,omitempty"`
 	AfterDeploy   *bool   `json:"after_deploy,omitempty"`
 	OnlyOnFailure *bool   `json:"only_on_failure,omitempty"`
+	Destroy       *bool   `json:"_destroy,omitempty"`
 }
 
 // List returns all stages
@@ -146,3 +187,94 @@ func (service *StageService) Delete(id int) (*Call, error) {
 
 	return call, call.Do(nil)
 }
+
+// DeployGroups returns the deploy groups a stage deploys to
+func (service *StageService) DeployGroups(id int) ([]*DeployGroup, *Call, error) {
+	path := fmt.Sprintf("/stages/%d/deploy_groups.json", id)
+	method := "GET"
+
+	call, err := service.s.NewCall(method, path, nil, nil, nil)
+	if err != nil {
+		return nil, call, err
+	}
+
+	type response struct {
+		DeployGroups []*DeployGroup `json:"deploy_groups,omitempty"`
+	}
+	var res response
+	err = call.Do(&res)
+	if err != nil {
+		return nil, call, err
+	}
+
+	return res.DeployGroups, call, nil
+}
+
+// SetDeployGroups replaces the deploy groups a stage deploys to
+func (service *StageService) SetDeployGroups(id int, deployGroupIDs []int) (*Stage, *Call, error) {
+	ids := make([]*int, len(deployGroupIDs))
+	for i := range deployGroupIDs {
+		ids[i] = Int(deployGroupIDs[i])
+	}
+
+	// an empty list has to be sent explicitly to remove all deploy groups
+	type request struct {
+		DeployGroupIds []*int `json:"deploy_group_ids"`
+	}
+	bytesArray, _ := json.Marshal(request{DeployGroupIds: ids})
+
+	path := fmt.Sprintf("/stages/%d.json", id)
+	method := "PUT"
+
+	call, err := service.s.NewCall(method, path, nil, nil, bytes.NewReader(bytesArray))
+	if err != nil {
+		return nil, call, err
+	}
+
+	var stage *Stage
+	err = call.Do(&stage)
+	if err != nil {
+		return nil, call, err
+	}
+
+	return stage, call, nil
+}
+
+// RefStatus returns whether a git reference is deployable to a stage
+func (service *StageService) RefStatus(id int, ref string) (*RefStatus, *Call, error) {
+	path := fmt.Sprintf("/stages/%d/ref_status.json", id)
+	method := "GET"
+
+	call, err := service.s.NewCall(method, path, map[string]string{"ref": ref}, nil, nil)
+	if err != nil {
+		return nil, call, err
+	}
+
+	var refStatus RefStatus
+	err = call.Do(&refStatus)
+	if err != nil {
+		return nil, call, err
+	}
+
+	return &refStatus, call, nil
+}
+
+// Changeset returns the changes between the reference currently deployed
+// to a stage and a candidate reference
+func (service *StageService) Changeset(id int, ref string) (*Changeset, *Call, error) {
+	path := fmt.Sprintf("/stages/%d/changeset.json", id)
+	method := "GET"
+
+	call, err := service.s.NewCall(method, path, map[string]string{"ref": ref}, nil, nil)
+	if err != nil {
+		return nil, call, err
+	}
+
+	var changeset Changeset
+	err = call.Do(&changeset)
+	if err != nil {
+		return nil, call, err
+	}
+
+	return &changeset, call, nil
+}
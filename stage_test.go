@@ -358,3 +358,215 @@ func TestStageServiceDelete_fail(t *testing.T) {
 	assert.NotNil(err)
 	assert.IsType(&Call{}, call)
 }
+
+func TestStageServiceDeployGroups(t *testing.T) {
+	var err error
+	assert := assert.New(t)
+
+	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal("GET", r.Method)
+		assert.Equal("/stages/1/deploy_groups.json", r.URL.Path)
+		checkHeaders(r, assert)
+
+		w.WriteHeader(200)
+		fmt.Fprintln(w, readTestData("deploy_groups.json"))
+	})
+
+	server := httptest.NewServer(handler)
+	defer server.Close()
+
+	client = New(token)
+	client.BaseURL = server.URL
+
+	deployGroups, call, err := client.Stages.DeployGroups(1)
+	assert.Nil(err)
+	assert.IsType(&Call{}, call)
+	assert.Equal(2, len(deployGroups))
+}
+
+func TestStageServiceSetDeployGroups(t *testing.T) {
+	var err error
+	assert := assert.New(t)
+
+	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal("PUT", r.Method)
+		assert.Equal("/stages/1.json", r.URL.Path)
+		checkHeaders(r, assert)
+
+		var payload map[string]interface{}
+		err := json.NewDecoder(r.Body).Decode(&payload)
+		assert.Nil(err)
+		assert.Equal([]interface{}{float64(1), float64(2)}, payload["deploy_group_ids"])
+
+		w.WriteHeader(200)
+		fmt.Fprintln(w, readTestData("stage.json"))
+	})
+
+	server := httptest.NewServer(handler)
+	defer server.Close()
+
+	client = New(token)
+	client.BaseURL = server.URL
+
+	stage, call, err := client.Stages.SetDeployGroups(1, []int{1, 2})
+	assert.Nil(err)
+	assert.IsType(&Call{}, call)
+	assert.Equal(2, len(stage.DeployGroupIds))
+}
+
+func TestStageServiceSetDeployGroups_empty(t *testing.T) {
+	var err error
+	assert := assert.New(t)
+
+	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var payload map[string]interface{}
+		err := json.NewDecoder(r.Body).Decode(&payload)
+		assert.Nil(err)
+		assert.Equal([]interface{}{}, payload["deploy_group_ids"])
+
+		w.WriteHeader(200)
+		fmt.Fprintln(w, readTestData("stage.json"))
+	})
+
+	server := httptest.NewServer(handler)
+	defer server.Close()
+
+	client = New(token)
+	client.BaseURL = server.URL
+
+	_, _, err = client.Stages.SetDeployGroups(1, []int{})
+	assert.Nil(err)
+}
+
+func TestStageServiceSetDeployGroups_fail(t *testing.T) {
+	var err error
+	assert := assert.New(t)
+
+	client = New(token)
+	client.BaseURL = "^http://localhost"
+
+	stage, call, err := client.Stages.SetDeployGroups(1, []int{1})
+	assert.NotNil(err)
+	assert.IsType(&Call{}, call)
+	assert.Nil(stage)
+}
+
+func ExampleStageService_RefStatus() {
+	client := New("token")
+
+	refStatus, _, err := client.Stages.RefStatus(3, "master")
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	if !refStatus.IsDeployable() {
+		for _, status := range refStatus.Failed() {
+			fmt.Println(*status.Context, *status.State)
+		}
+		return
+	}
+
+	changeset, _, err := client.Stages.Changeset(3, "master")
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	fmt.Println(len(changeset.Commits), "commits will be deployed")
+}
+
+func TestStageServiceRefStatus(t *testing.T) {
+	var err error
+	assert := assert.New(t)
+
+	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal("GET", r.Method)
+		assert.Equal("/stages/1/ref_status.json", r.URL.Path)
+		assert.Equal("release/v2", r.URL.Query().Get("ref"))
+		checkHeaders(r, assert)
+
+		w.WriteHeader(200)
+		fmt.Fprintln(w, readTestData("ref_status.json"))
+	})
+
+	server := httptest.NewServer(handler)
+	defer server.Close()
+
+	client = New(token)
+	client.BaseURL = server.URL
+
+	refStatus, call, err := client.Stages.RefStatus(1, "release/v2")
+	assert.Nil(err)
+	assert.IsType(&Call{}, call)
+	assert.Equal(RefStatePending, *refStatus.State)
+	assert.False(refStatus.IsDeployable())
+	assert.Equal(2, len(refStatus.Statuses))
+	assert.Equal(1, len(refStatus.Failed()))
+	assert.Equal("security/scan", *refStatus.Failed()[0].Context)
+
+	refStatus.State = String(RefStateSuccess)
+	assert.True(refStatus.IsDeployable())
+}
+
+func TestStageServiceRefStatus_fail(t *testing.T) {
+	var err error
+	assert := assert.New(t)
+
+	client = New(token)
+	client.BaseURL = "^http://localhost"
+
+	refStatus, call, err := client.Stages.RefStatus(1, "master")
+	assert.NotNil(err)
+	assert.IsType(&Call{}, call)
+	assert.Nil(refStatus)
+}
+
+func TestStageServiceChangeset(t *testing.T) {
+	var err error
+	assert := assert.New(t)
+
+	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal("GET", r.Method)
+		assert.Equal("/stages/1/changeset.json", r.URL.Path)
+		assert.Equal("master", r.URL.Query().Get("ref"))
+		checkHeaders(r, assert)
+
+		w.WriteHeader(200)
+		fmt.Fprintln(w, readTestData("stage_changeset.json"))
+	})
+
+	server := httptest.NewServer(handler)
+	defer server.Close()
+
+	client = New(token)
+	client.BaseURL = server.URL
+
+	changeset, call, err := client.Stages.Changeset(1, "master")
+	assert.Nil(err)
+	assert.IsType(&Call{}, call)
+	assert.False(changeset.IsEmpty())
+	assert.Equal("v1", *changeset.Previous)
+	assert.Equal(2, len(changeset.Commits))
+	assert.Equal(1, len(changeset.PullRequests))
+	assert.Equal(2, len(changeset.Files))
+}
+
+func TestStageServiceChangeset_fail(t *testing.T) {
+	var err error
+	assert := assert.New(t)
+
+	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(404)
+		fmt.Fprintln(w, readTestData("error-notfound.json"))
+	})
+
+	server := httptest.NewServer(handler)
+	defer server.Close()
+
+	client = New(token)
+	client.BaseURL = server.URL
+
+	changeset, call, err := client.Stages.Changeset(1, "unknown")
+	assert.NotNil(err)
+	assert.IsType(&Call{}, call)
+	assert.Nil(changeset)
+}
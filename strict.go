@@ -0,0 +1,175 @@
+package samson
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+)
+
+// StrictError is returned in strict mode when a response doesn't map
+// cleanly onto the models and no warnings callback is set
+type StrictError struct {
+	Warnings []string
+}
+
+func (e StrictError) Error() string {
+	return "strict decoding: " + strings.Join(e.Warnings, "; ")
+}
+
+// StrictWarnings reports json fields of data which have no matching field
+// in the model type of v, and model fields sharing the same json name
+// Only the type of v is inspected, data is not decoded into it
+func StrictWarnings(data []byte, v interface{}) ([]string, error) {
+	var raw interface{}
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.UseNumber()
+	err := decoder.Decode(&raw)
+	if err != nil {
+		return nil, err
+	}
+
+	t := reflect.TypeOf(v)
+	warnings := duplicateTags(t, map[reflect.Type]bool{})
+	warnings = append(warnings, unknownFields("", raw, t)...)
+
+	return warnings, nil
+}
+
+// jsonField is a struct field as seen by encoding/json
+type jsonField struct {
+	name  string
+	field reflect.StructField
+}
+
+// jsonFields returns the json visible fields of a struct, including the
+// ones promoted from embedded structs
+func jsonFields(t reflect.Type) []jsonField {
+	var fields []jsonField
+	for i := 0; i < t.NumField(); i++ {
+		f := t.Field(i)
+		tag := f.Tag.Get("json")
+		if tag == "-" {
+			continue
+		}
+
+		name := strings.Split(tag, ",")[0]
+		ft := f.Type
+		if ft.Kind() == reflect.Ptr {
+			ft = ft.Elem()
+		}
+		if f.Anonymous && name == "" && ft.Kind() == reflect.Struct {
+			fields = append(fields, jsonFields(ft)...)
+			continue
+		}
+		if f.PkgPath != "" {
+			continue
+		}
+		if name == "" {
+			name = f.Name
+		}
+
+		fields = append(fields, jsonField{name: name, field: f})
+	}
+
+	return fields
+}
+
+// duplicateTags reports struct fields mapped to the same json name, which
+// encoding/json silently ignores
+func duplicateTags(t reflect.Type, seen map[reflect.Type]bool) []string {
+	for t.Kind() == reflect.Ptr || t.Kind() == reflect.Slice || t.Kind() == reflect.Array || t.Kind() == reflect.Map {
+		t = t.Elem()
+	}
+	if t.Kind() != reflect.Struct || seen[t] {
+		return nil
+	}
+	seen[t] = true
+
+	var warnings []string
+	names := map[string]string{}
+	for _, f := range jsonFields(t) {
+		if other, ok := names[f.name]; ok {
+			warnings = append(warnings, fmt.Sprintf("%s: fields %s and %s share json name %q", typeName(t), other, f.field.Name, f.name))
+		} else {
+			names[f.name] = f.field.Name
+		}
+		warnings = append(warnings, duplicateTags(f.field.Type, seen)...)
+	}
+
+	return warnings
+}
+
+// unknownFields walks raw json alongside the model type and reports
+// object keys the model can't hold
+func unknownFields(path string, raw interface{}, t reflect.Type) []string {
+	for t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+
+	var warnings []string
+	switch value := raw.(type) {
+	case map[string]interface{}:
+		switch t.Kind() {
+		case reflect.Map:
+			for _, key := range sortedKeys(value) {
+				warnings = append(warnings, unknownFields(path+"."+key, value[key], t.Elem())...)
+			}
+		case reflect.Struct:
+			fields := jsonFields(t)
+			for _, key := range sortedKeys(value) {
+				f, ok := lookupField(fields, key)
+				if !ok {
+					warnings = append(warnings, fmt.Sprintf("%s: unknown field %s", typeName(t), strings.TrimPrefix(path+"."+key, ".")))
+					continue
+				}
+				warnings = append(warnings, unknownFields(path+"."+key, value[key], f.Type)...)
+			}
+		}
+	case []interface{}:
+		if t.Kind() == reflect.Slice || t.Kind() == reflect.Array {
+			for i, item := range value {
+				warnings = append(warnings, unknownFields(fmt.Sprintf("%s[%d]", path, i), item, t.Elem())...)
+			}
+		}
+	}
+
+	return warnings
+}
+
+// lookupField matches a json key like encoding/json does, preferring an
+// exact match over a case insensitive one
+func lookupField(fields []jsonField, key string) (reflect.StructField, bool) {
+	for _, f := range fields {
+		if f.name == key {
+			return f.field, true
+		}
+	}
+	for _, f := range fields {
+		if strings.EqualFold(f.name, key) {
+			return f.field, true
+		}
+	}
+
+	return reflect.StructField{}, false
+}
+
+func sortedKeys(m map[string]interface{}) []string {
+	keys := make([]string, 0, len(m))
+	for key := range m {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	return keys
+}
+
+func typeName(t reflect.Type) string {
+	if t.Name() == "" {
+		return "response"
+	}
+
+	return t.Name()
+}
@@ -0,0 +1,409 @@
+package samson
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// fixtureModels maps every fixture in testdata/ to the model it's decoded into
+var fixtureModels = map[string]func() interface{}{
+	"access_token_new.json": func() interface{} { return &AccessToken{} },
+	"access_tokens.json": func() interface{} {
+		return &struct {
+			AccessTokens []*AccessToken `json:"access_tokens"`
+		}{}
+	},
+	"audits.json": func() interface{} {
+		return &struct {
+			Audits []*Audit `json:"audits"`
+		}{}
+	},
+	"command.json":        func() interface{} { return &Command{} },
+	"command_global.json": func() interface{} { return &Command{} },
+	"commands.json": func() interface{} {
+		return &struct {
+			Commands []*Command `json:"commands"`
+		}{}
+	},
+	"deploy.json":          func() interface{} { return &Deploy{} },
+	"deploy_approved.json": func() interface{} { return &Deploy{} },
+	"deploy_group.json":    func() interface{} { return &DeployGroup{} },
+	"deploy_groups.json": func() interface{} {
+		return &struct {
+			DeployGroups []*DeployGroup `json:"deploy_groups"`
+		}{}
+	},
+	"deploy_own.json": func() interface{} { return &Deploy{} },
+	"deploys_pending.json": func() interface{} {
+		return &struct {
+			Deploys []*Deploy `json:"deploys"`
+		}{}
+	},
+	"environment_prod.json":           func() interface{} { return &Environment{} },
+	"environment_staging.json":        func() interface{} { return &Environment{} },
+	"environment_variable_group.json": func() interface{} { return &EnvironmentVariableGroup{} },
+	"environment_variable_groups.json": func() interface{} {
+		return &struct {
+			EnvironmentVariableGroups []*EnvironmentVariableGroup `json:"environment_variable_groups"`
+		}{}
+	},
+	"environments.json": func() interface{} {
+		return &struct {
+			Environments []*Environment `json:"environments"`
+		}{}
+	},
+	"error-notfound.json":     func() interface{} { return &ErrorResponse{} },
+	"error-unknown.json":      func() interface{} { return &ErrorResponse{} },
+	"integration.json":        func() interface{} { return &IntegrationResponse{} },
+	"kubernetes_cluster.json": func() interface{} { return &KubernetesCluster{} },
+	"kubernetes_clusters.json": func() interface{} {
+		return &struct {
+			KubernetesClusters []*KubernetesCluster `json:"kubernetes_clusters"`
+		}{}
+	},
+	"kubernetes_deploy_group_role.json": func() interface{} { return &KubernetesDeployGroupRole{} },
+	"kubernetes_deploy_group_roles.json": func() interface{} {
+		return &struct {
+			KubernetesDeployGroupRoles []*KubernetesDeployGroupRole `json:"kubernetes_deploy_group_roles"`
+		}{}
+	},
+	"kubernetes_role.json": func() interface{} { return &KubernetesRole{} },
+	"kubernetes_roles.json": func() interface{} {
+		return &struct {
+			KubernetesRoles []*KubernetesRole `json:"kubernetes_roles"`
+		}{}
+	},
+	"lock.json": func() interface{} { return &Lock{} },
+	"locks.json": func() interface{} {
+		return &struct {
+			Locks []*Lock `json:"locks"`
+		}{}
+	},
+	"outbound_webhook.json": func() interface{} { return &OutboundWebhook{} },
+	"outbound_webhooks.json": func() interface{} {
+		return &struct {
+			OutboundWebhooks []*OutboundWebhook `json:"outbound_webhooks"`
+		}{}
+	},
+	"project.json": func() interface{} { return &Project{} },
+	"projects.json": func() interface{} {
+		return &struct {
+			Projects []*Project `json:"projects"`
+		}{}
+	},
+	"ref_status.json":        func() interface{} { return &RefStatus{} },
+	"release.json":           func() interface{} { return &Release{} },
+	"release_changeset.json": func() interface{} { return &Changeset{} },
+	"release_deploys.json": func() interface{} {
+		return &struct {
+			Deploys []*Deploy `json:"deploys"`
+		}{}
+	},
+	"releases.json": func() interface{} {
+		return &struct {
+			Releases []*Release `json:"releases"`
+		}{}
+	},
+	"secret.json": func() interface{} { return &Secret{} },
+	"secrets.json": func() interface{} {
+		return &struct {
+			Keys []string `json:"keys"`
+		}{}
+	},
+	"stage.json":           func() interface{} { return &Stage{} },
+	"stage_changeset.json": func() interface{} { return &Changeset{} },
+	"stages.json": func() interface{} {
+		return &struct {
+			Stages []*Stage `json:"stages"`
+		}{}
+	},
+	"stages_release.json": func() interface{} {
+		return &struct {
+			Stages []*Stage `json:"stages"`
+		}{}
+	},
+	"user.json": func() interface{} { return &User{} },
+	"users.json": func() interface{} {
+		return &struct {
+			Users []*User `json:"users"`
+		}{}
+	},
+	"webhook.json": func() interface{} { return &Webhook{} },
+	"webhooks.json": func() interface{} {
+		return &struct {
+			Webhooks []*Webhook `json:"webhooks"`
+		}{}
+	},
+}
+
+// assertRoundTrip checks a fixture decodes without unknown fields and
+// encodes back to the fixture without losing values
+func assertRoundTrip(assert *assert.Assertions, fileName string, newModel func() interface{}) {
+	data := []byte(readTestData(fileName))
+
+	model := newModel()
+	err := json.Unmarshal(data, model)
+	assert.Nil(err, fileName)
+
+	warnings, err := StrictWarnings(data, model)
+	assert.Nil(err, fileName)
+	assert.Empty(warnings, fileName)
+
+	encoded, err := json.Marshal(model)
+	assert.Nil(err, fileName)
+
+	var fixture, reencoded interface{}
+	assert.Nil(json.Unmarshal(data, &fixture), fileName)
+	assert.Nil(json.Unmarshal(encoded, &reencoded), fileName)
+
+	assert.Empty(jsonDifferences("", normalizeFixture(fixture), reencoded), fileName+" loses values")
+}
+
+// normalizeFixture drops null values and empty lists, which decode to
+// unset fields and are left out by omitempty
+func normalizeFixture(v interface{}) interface{} {
+	switch value := v.(type) {
+	case map[string]interface{}:
+		normalized := map[string]interface{}{}
+		for key, item := range value {
+			if list, ok := item.([]interface{}); item == nil || ok && len(list) == 0 {
+				continue
+			}
+			normalized[key] = normalizeFixture(item)
+		}
+		return normalized
+	case []interface{}:
+		normalized := make([]interface{}, len(value))
+		for i, item := range value {
+			normalized[i] = normalizeFixture(item)
+		}
+		return normalized
+	}
+
+	return v
+}
+
+// jsonDifferences lists the paths where decoded json values differ
+func jsonDifferences(path string, expected, actual interface{}) []string {
+	switch e := expected.(type) {
+	case map[string]interface{}:
+		a, ok := actual.(map[string]interface{})
+		if !ok {
+			break
+		}
+		var differences []string
+		keys := map[string]interface{}{}
+		for key := range e {
+			keys[key] = nil
+		}
+		for key := range a {
+			keys[key] = nil
+		}
+		for _, key := range sortedKeys(keys) {
+			differences = append(differences, jsonDifferences(path+"."+key, e[key], a[key])...)
+		}
+		return differences
+	case []interface{}:
+		a, ok := actual.([]interface{})
+		if !ok || len(a) != len(e) {
+			break
+		}
+		var differences []string
+		for i := range e {
+			differences = append(differences, jsonDifferences(fmt.Sprintf("%s[%d]", path, i), e[i], a[i])...)
+		}
+		return differences
+	}
+
+	if sameValue(expected, actual) {
+		return nil
+	}
+
+	return []string{fmt.Sprintf("%s: %v became %v", strings.TrimPrefix(path, "."), expected, actual)}
+}
+
+// sameValue compares json values, accepting the forms models normalize:
+// string ids and flags, quantities and times with fractional seconds
+func sameValue(expected, actual interface{}) bool {
+	if reflect.DeepEqual(expected, actual) {
+		return true
+	}
+
+	s, ok := expected.(string)
+	if !ok {
+		return false
+	}
+	data, _ := json.Marshal(s)
+
+	switch a := actual.(type) {
+	case bool:
+		var b FlexBool
+		return json.Unmarshal(data, &b) == nil && bool(b) == a
+	case float64:
+		var i FlexInt
+		if json.Unmarshal(data, &i) == nil && float64(i) == a {
+			return true
+		}
+		var cpu CPU
+		if json.Unmarshal(data, &cpu) == nil && float64(cpu) == a {
+			return true
+		}
+		var memory Memory
+		return json.Unmarshal(data, &memory) == nil && float64(memory.Mebibytes()) == a
+	case string:
+		e, err := time.Parse(time.RFC3339Nano, s)
+		if err != nil {
+			return false
+		}
+		t, err := time.Parse(time.RFC3339Nano, a)
+		return err == nil && e.Equal(t)
+	}
+
+	return false
+}
+
+func TestFixturesRoundTrip(t *testing.T) {
+	assert := assert.New(t)
+
+	files, err := filepath.Glob("testdata/*.json")
+	assert.Nil(err)
+	assert.NotEmpty(files)
+
+	for _, file := range files {
+		fileName := filepath.Base(file)
+		newModel, ok := fixtureModels[fileName]
+		assert.True(ok, "no model registered for fixture "+fileName)
+		if !ok {
+			continue
+		}
+
+		assertRoundTrip(assert, fileName, newModel)
+	}
+}
+
+func TestStrictWarnings(t *testing.T) {
+	assert := assert.New(t)
+
+	data := []byte(`{"id": 1, "name": "Foo", "colour": "red", "commands": [{"id": 2, "shell": "bash"}]}`)
+	type model struct {
+		ID       *int       `json:"id,omitempty"`
+		Name     *string    `json:"name,omitempty"`
+		Commands []*Command `json:"commands,omitempty"`
+	}
+
+	warnings, err := StrictWarnings(data, &model{})
+	assert.Nil(err)
+	assert.Equal([]string{
+		"model: unknown field colour",
+		"Command: unknown field commands[0].shell",
+	}, warnings)
+
+	warnings, err = StrictWarnings([]byte(`{"ID": 1}`), &model{})
+	assert.Nil(err)
+	assert.Empty(warnings)
+
+	_, err = StrictWarnings([]byte(`malformed json`), &model{})
+	assert.NotNil(err)
+}
+
+func TestStrictWarnings_duplicateTags(t *testing.T) {
+	assert := assert.New(t)
+
+	// built at runtime, go vet rejects the duplicate tag in source
+	model := reflect.StructOf([]reflect.StructField{
+		{Name: "TemplateStageID", Type: reflect.TypeOf(Int(0)), Tag: `json:"template_stage_id"`},
+		{Name: "TamplateStageID", Type: reflect.TypeOf(Int(0)), Tag: `json:"template_stage_id,omitempty"`},
+	})
+
+	warnings, err := StrictWarnings([]byte(`{}`), reflect.New(model).Interface())
+	assert.Nil(err)
+	assert.Equal([]string{`response: fields TemplateStageID and TamplateStageID share json name "template_stage_id"`}, warnings)
+
+	warnings, err = StrictWarnings([]byte(`{}`), &Stage{})
+	assert.Nil(err)
+	assert.Empty(warnings)
+}
+
+func TestSamsonStrict(t *testing.T) {
+	assert := assert.New(t)
+
+	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{"id": 1, "name": "Foo", "colour": "red"}`)
+	})
+	server = httptest.NewServer(handler)
+	defer server.Close()
+
+	client = New(token)
+	client.BaseURL = server.URL
+
+	project, _, err := client.Projects.Get(1)
+	assert.Nil(err)
+	assert.Equal("Foo", *project.Name)
+
+	client.Strict = true
+	project, _, err = client.Projects.Get(1)
+	assert.NotNil(err)
+	assert.Nil(project)
+	assert.Equal([]string{"Project: unknown field colour"}, err.(StrictError).Warnings)
+	assert.True(strings.HasPrefix(err.Error(), "strict decoding: "))
+
+	var warnings []string
+	client.OnWarnings = func(w []string) {
+		warnings = append(warnings, w...)
+	}
+	project, _, err = client.Projects.Get(1)
+	assert.Nil(err)
+	assert.Equal("Foo", *project.Name)
+	assert.Equal([]string{"Project: unknown field colour"}, warnings)
+}
+
+func TestSamsonStrict_fail(t *testing.T) {
+	assert := assert.New(t)
+
+	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `malformed json response`)
+	})
+	server = httptest.NewServer(handler)
+	defer server.Close()
+
+	client = New(token)
+	client.BaseURL = server.URL
+	client.Strict = true
+
+	_, _, err := client.Projects.Get(1)
+	assert.NotNil(err)
+}
+
+func ExampleStrictWarnings() {
+	data, _ := ioutil.ReadFile("testdata/stage.json")
+
+	var stage Stage
+	json.Unmarshal(data, &stage)
+
+	warnings, _ := StrictWarnings(data, &stage)
+	for _, warning := range warnings {
+		fmt.Println(warning)
+	}
+}
+
+func ExampleSamson_strict() {
+	client := New(token)
+	client.Strict = true
+	client.OnWarnings = func(warnings []string) {
+		for _, warning := range warnings {
+			fmt.Println(warning)
+		}
+	}
+
+	client.Projects.Get(1)
+}
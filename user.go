@@ -0,0 +1,218 @@
+package samson
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+)
+
+// UserService service
+type UserService service
+
+// ErrProjectSuperAdmin is returned when granting the super admin role in a project
+var ErrProjectSuperAdmin = errors.New("project roles can not be super admin")
+
+// Role of a user, globally or per project
+type Role int
+
+// Roles in ascending order of permissions
+// Project roles can not be super admin
+const (
+	RoleViewer Role = iota
+	RoleDeployer
+	RoleAdmin
+	RoleSuperAdmin
+)
+
+var roleNames = map[Role]string{
+	RoleViewer:     "viewer",
+	RoleDeployer:   "deployer",
+	RoleAdmin:      "admin",
+	RoleSuperAdmin: "super_admin",
+}
+
+// String returns the name of the role
+func (r Role) String() string {
+	if name, ok := roleNames[r]; ok {
+		return name
+	}
+
+	return fmt.Sprintf("Role(%d)", int(r))
+}
+
+// ParseRole returns the role with the given name
+func ParseRole(name string) (Role, error) {
+	for role, roleName := range roleNames {
+		if roleName == name {
+			return role, nil
+		}
+	}
+
+	return RoleViewer, fmt.Errorf("unknown role %q", name)
+}
+
+// User model
+type User struct {
+	ID               *int               `json:"id,omitempty"`
+	Name             *string            `json:"name,omitempty"`
+	Email            *string            `json:"email,omitempty"`
+	ExternalID       *string            `json:"external_id,omitempty"`
+	RoleID           *Role              `json:"role_id,omitempty"`
+	UserProjectRoles []*UserProjectRole `json:"user_project_roles,omitempty"`
+	LastLoginAt      *time.Time         `json:"last_login_at,omitempty"`
+	CreatedAt        *time.Time         `json:"created_at,omitempty"`
+	UpdatedAt        *time.Time         `json:"updated_at,omitempty"`
+	DeletedAt        *time.Time         `json:"deleted_at,omitempty"`
+}
+
+// UserProjectRole model for the role of a user in a project
+type UserProjectRole struct {
+	ProjectID *int  `json:"project_id,omitempty"`
+	RoleID    *Role `json:"role_id,omitempty"`
+}
+
+// Role returns the global role of the user
+func (u *User) Role() Role {
+	if u.RoleID == nil {
+		return RoleViewer
+	}
+
+	return *u.RoleID
+}
+
+// ProjectRole returns the effective role of the user in a project,
+// which is the higher of the global and the project role
+func (u *User) ProjectRole(projectID int) Role {
+	role := u.Role()
+	for _, projectRole := range u.UserProjectRoles {
+		if projectRole.ProjectID == nil || *projectRole.ProjectID != projectID || projectRole.RoleID == nil {
+			continue
+		}
+
+		if *projectRole.RoleID > role {
+			role = *projectRole.RoleID
+		}
+	}
+
+	return role
+}
+
+// List returns all users
+// Users are filtered by name or email when search is not empty
+func (service *UserService) List(search string) ([]*User, *Call, error) {
+	path := "/users.json"
+	method := "GET"
+
+	var queryParams map[string]string
+	if search != "" {
+		queryParams = map[string]string{"search": search}
+	}
+
+	call, err := service.s.NewCall(method, path, queryParams, nil, nil)
+	if err != nil {
+		return nil, call, err
+	}
+
+	type response struct {
+		Users []*User `json:"users,omitempty"`
+	}
+	var res response
+	err = call.Do(&res)
+	if err != nil {
+		return nil, call, err
+	}
+
+	return res.Users, call, nil
+}
+
+// Get returns a single user resource
+func (service *UserService) Get(id int) (*User, *Call, error) {
+	path := fmt.Sprintf("/users/%d.json", id)
+	method := "GET"
+
+	return service.get(method, path)
+}
+
+// Current returns the user the client token belongs to
+func (service *UserService) Current() (*User, *Call, error) {
+	path := "/users/current.json"
+	method := "GET"
+
+	return service.get(method, path)
+}
+
+func (service *UserService) get(method, path string) (*User, *Call, error) {
+	call, err := service.s.NewCall(method, path, nil, nil, nil)
+	if err != nil {
+		return nil, call, err
+	}
+
+	var user User
+	err = call.Do(&user)
+	if err != nil {
+		return nil, call, err
+	}
+
+	return &user, call, nil
+}
+
+// SetRole grants a global role to a user
+func (service *UserService) SetRole(id int, role Role) (*User, *Call, error) {
+	path := fmt.Sprintf("/users/%d.json", id)
+	method := "PUT"
+
+	return service.setRole(method, path, role)
+}
+
+// RevokeRole revokes the global role of a user, leaving viewer access only
+func (service *UserService) RevokeRole(id int) (*User, *Call, error) {
+	return service.SetRole(id, RoleViewer)
+}
+
+// SetProjectRole grants a role in a project to a user
+// ErrProjectSuperAdmin is returned for RoleSuperAdmin without making a request
+func (service *UserService) SetProjectRole(id int, projectID int, role Role) (*User, *Call, error) {
+	if role == RoleSuperAdmin {
+		return nil, nil, ErrProjectSuperAdmin
+	}
+
+	path := fmt.Sprintf("/projects/%d/users/%d.json", projectID, id)
+	method := "PUT"
+
+	return service.setRole(method, path, role)
+}
+
+// RevokeProjectRole revokes the role of a user in a project
+func (service *UserService) RevokeProjectRole(id int, projectID int) (*Call, error) {
+	path := fmt.Sprintf("/projects/%d/users/%d.json", projectID, id)
+	method := "DELETE"
+
+	call, err := service.s.NewCall(method, path, nil, nil, nil)
+	if err != nil {
+		return call, err
+	}
+
+	return call, call.Do(nil)
+}
+
+func (service *UserService) setRole(method, path string, role Role) (*User, *Call, error) {
+	type request struct {
+		RoleID Role `json:"role_id"`
+	}
+	bytesArray, _ := json.Marshal(request{RoleID: role})
+
+	call, err := service.s.NewCall(method, path, nil, nil, bytes.NewReader(bytesArray))
+	if err != nil {
+		return nil, call, err
+	}
+
+	var user User
+	err = call.Do(&user)
+	if err != nil {
+		return nil, call, err
+	}
+
+	return &user, call, nil
+}
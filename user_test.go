@@ -0,0 +1,331 @@
+package samson
+
+import (
+	"encoding/json"
+	"fmt"
+	"log"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func ExampleUserService_List() {
+	client := New("token")
+
+	users, _, err := client.Users.List("example.com")
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	for _, user := range users {
+		fmt.Println(*user.Email, user.Role())
+	}
+}
+
+func ExampleUserService_SetProjectRole() {
+	client := New("token")
+
+	_, _, err := client.Users.SetProjectRole(2, 1, RoleDeployer)
+	if err != nil {
+		log.Fatal(err)
+	}
+}
+
+func TestRoleString(t *testing.T) {
+	assert := assert.New(t)
+
+	assert.Equal("viewer", RoleViewer.String())
+	assert.Equal("deployer", RoleDeployer.String())
+	assert.Equal("admin", RoleAdmin.String())
+	assert.Equal("super_admin", RoleSuperAdmin.String())
+	assert.Equal("Role(7)", Role(7).String())
+}
+
+func TestParseRole(t *testing.T) {
+	assert := assert.New(t)
+
+	role, err := ParseRole("deployer")
+	assert.Nil(err)
+	assert.Equal(RoleDeployer, role)
+
+	_, err = ParseRole("owner")
+	assert.NotNil(err)
+}
+
+func TestUserProjectRole(t *testing.T) {
+	assert := assert.New(t)
+
+	var user User
+	err := json.Unmarshal([]byte(readTestData("user.json")), &user)
+	assert.Nil(err)
+
+	assert.Equal(RoleViewer, user.Role())
+	assert.Equal(RoleDeployer, user.ProjectRole(2))
+	assert.Equal(RoleViewer, user.ProjectRole(1))
+
+	user.RoleID = new(Role)
+	*user.RoleID = RoleAdmin
+	assert.Equal(RoleAdmin, user.ProjectRole(2))
+}
+
+func TestUserServiceList(t *testing.T) {
+	var err error
+	assert := assert.New(t)
+
+	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal("GET", r.Method)
+		assert.Equal("/users.json", r.URL.Path)
+		assert.Equal("example.com", r.URL.Query().Get("search"))
+		checkHeaders(r, assert)
+
+		w.WriteHeader(200)
+		fmt.Fprintln(w, readTestData("users.json"))
+	})
+
+	server := httptest.NewServer(handler)
+	defer server.Close()
+
+	client = New(token)
+	client.BaseURL = server.URL
+
+	users, call, err := client.Users.List("example.com")
+	assert.Nil(err)
+	assert.IsType(&Call{}, call)
+	assert.Equal(2, len(users))
+	assert.Equal(RoleAdmin, users[0].Role())
+}
+
+func TestUserServiceList_fail(t *testing.T) {
+	var err error
+	assert := assert.New(t)
+
+	client = New(token)
+	client.BaseURL = "^http://localhost"
+
+	users, call, err := client.Users.List("")
+	assert.NotNil(err)
+	assert.IsType(&Call{}, call)
+	assert.Nil(users)
+}
+
+func TestUserServiceGet(t *testing.T) {
+	var err error
+	assert := assert.New(t)
+
+	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal("GET", r.Method)
+		assert.Equal("/users/2.json", r.URL.Path)
+		checkHeaders(r, assert)
+
+		w.WriteHeader(200)
+		fmt.Fprintln(w, readTestData("user.json"))
+	})
+
+	server := httptest.NewServer(handler)
+	defer server.Close()
+
+	client = New(token)
+	client.BaseURL = server.URL
+
+	user, call, err := client.Users.Get(2)
+	assert.Nil(err)
+	assert.IsType(&Call{}, call)
+	assert.Equal("John Roe", *user.Name)
+}
+
+func TestUserServiceCurrent(t *testing.T) {
+	var err error
+	assert := assert.New(t)
+
+	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal("GET", r.Method)
+		assert.Equal("/users/current.json", r.URL.Path)
+		checkHeaders(r, assert)
+
+		w.WriteHeader(200)
+		fmt.Fprintln(w, readTestData("user.json"))
+	})
+
+	server := httptest.NewServer(handler)
+	defer server.Close()
+
+	client = New(token)
+	client.BaseURL = server.URL
+
+	user, call, err := client.Users.Current()
+	assert.Nil(err)
+	assert.IsType(&Call{}, call)
+	assert.Equal(2, *user.ID)
+}
+
+func TestUserServiceCurrent_fail(t *testing.T) {
+	var err error
+	assert := assert.New(t)
+
+	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(200)
+		fmt.Fprintln(w, "malformed json response")
+	})
+
+	server := httptest.NewServer(handler)
+	defer server.Close()
+
+	client = New(token)
+	client.BaseURL = server.URL
+
+	user, call, err := client.Users.Current()
+	assert.NotNil(err)
+	assert.IsType(&Call{}, call)
+	assert.Nil(user)
+}
+
+func TestUserServiceSetRole(t *testing.T) {
+	var err error
+	assert := assert.New(t)
+
+	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal("PUT", r.Method)
+		assert.Equal("/users/2.json", r.URL.Path)
+		checkHeaders(r, assert)
+
+		var payload map[string]interface{}
+		err := json.NewDecoder(r.Body).Decode(&payload)
+		assert.Nil(err)
+		assert.Equal(float64(RoleAdmin), payload["role_id"])
+
+		w.WriteHeader(200)
+		fmt.Fprintln(w, readTestData("user.json"))
+	})
+
+	server := httptest.NewServer(handler)
+	defer server.Close()
+
+	client = New(token)
+	client.BaseURL = server.URL
+
+	user, call, err := client.Users.SetRole(2, RoleAdmin)
+	assert.Nil(err)
+	assert.IsType(&Call{}, call)
+	assert.Equal(2, *user.ID)
+}
+
+func TestUserServiceRevokeRole(t *testing.T) {
+	var err error
+	assert := assert.New(t)
+
+	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal("PUT", r.Method)
+		assert.Equal("/users/2.json", r.URL.Path)
+
+		var payload map[string]interface{}
+		err := json.NewDecoder(r.Body).Decode(&payload)
+		assert.Nil(err)
+		assert.Equal(float64(RoleViewer), payload["role_id"])
+
+		w.WriteHeader(200)
+		fmt.Fprintln(w, readTestData("user.json"))
+	})
+
+	server := httptest.NewServer(handler)
+	defer server.Close()
+
+	client = New(token)
+	client.BaseURL = server.URL
+
+	user, _, err := client.Users.RevokeRole(2)
+	assert.Nil(err)
+	assert.Equal(RoleViewer, user.Role())
+}
+
+func TestUserServiceSetProjectRole(t *testing.T) {
+	var err error
+	assert := assert.New(t)
+
+	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal("PUT", r.Method)
+		assert.Equal("/projects/2/users/2.json", r.URL.Path)
+		checkHeaders(r, assert)
+
+		var payload map[string]interface{}
+		err := json.NewDecoder(r.Body).Decode(&payload)
+		assert.Nil(err)
+		assert.Equal(float64(RoleDeployer), payload["role_id"])
+
+		w.WriteHeader(200)
+		fmt.Fprintln(w, readTestData("user.json"))
+	})
+
+	server := httptest.NewServer(handler)
+	defer server.Close()
+
+	client = New(token)
+	client.BaseURL = server.URL
+
+	user, call, err := client.Users.SetProjectRole(2, 2, RoleDeployer)
+	assert.Nil(err)
+	assert.IsType(&Call{}, call)
+	assert.Equal(RoleDeployer, user.ProjectRole(2))
+}
+
+func TestUserServiceSetProjectRole_fail(t *testing.T) {
+	var err error
+	assert := assert.New(t)
+
+	client = New(token)
+	client.BaseURL = "^http://localhost"
+
+	user, call, err := client.Users.SetProjectRole(2, 2, RoleDeployer)
+	assert.NotNil(err)
+	assert.IsType(&Call{}, call)
+	assert.Nil(user)
+}
+
+func TestUserServiceSetProjectRole_superadmin(t *testing.T) {
+	var err error
+	assert := assert.New(t)
+
+	requests := 0
+	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		w.WriteHeader(200)
+		fmt.Fprintln(w, readTestData("user.json"))
+	})
+
+	server := httptest.NewServer(handler)
+	defer server.Close()
+
+	client = New(token)
+	client.BaseURL = server.URL
+
+	user, call, err := client.Users.SetProjectRole(2, 2, RoleSuperAdmin)
+	assert.Equal(ErrProjectSuperAdmin, err)
+	assert.Nil(call)
+	assert.Nil(user)
+	assert.Equal(0, requests)
+}
+
+func TestUserServiceRevokeProjectRole(t *testing.T) {
+	var err error
+	assert := assert.New(t)
+
+	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal("DELETE", r.Method)
+		assert.Equal("/projects/2/users/2.json", r.URL.Path)
+		checkHeaders(r, assert)
+
+		w.WriteHeader(200)
+		fmt.Fprintln(w, "")
+	})
+
+	server := httptest.NewServer(handler)
+	defer server.Close()
+
+	client = New(token)
+	client.BaseURL = server.URL
+
+	call, err := client.Users.RevokeProjectRole(2, 2)
+	assert.Nil(err)
+	assert.IsType(&Call{}, call)
+}
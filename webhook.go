@@ -0,0 +1,165 @@
+package samson
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"time"
+)
+
+// WebhookService service
+type WebhookService service
+
+// Sources a webhook accepts builds from
+const (
+	WebhookSourceAny       = "any"
+	WebhookSourceAnyCI     = "any_ci"
+	WebhookSourceAnyCode   = "any_code"
+	WebhookSourceGeneric   = "generic"
+	WebhookSourceGithub    = "github"
+	WebhookSourceTravis    = "travis"
+	WebhookSourceJenkins   = "jenkins"
+	WebhookSourceCircleCI  = "circleci"
+	WebhookSourceSemaphore = "semaphore"
+	WebhookSourceBuildkite = "buildkite"
+)
+
+// Webhook model for the incoming webhooks which deploy a stage when
+// a build of a matching branch is reported
+type Webhook struct {
+	ID        *int       `json:"id,omitempty"`
+	ProjectID *int       `json:"project_id,omitempty"`
+	StageID   *int       `json:"stage_id,omitempty"`
+	Branch    *string    `json:"branch,omitempty"`
+	Source    *string    `json:"source,omitempty"`
+	CreatedAt *time.Time `json:"created_at,omitempty"`
+	UpdatedAt *time.Time `json:"updated_at,omitempty"`
+	DeletedAt *time.Time `json:"deleted_at,omitempty"`
+}
+
+// IntegrationResponse model for the response of an integration endpoint
+type IntegrationResponse struct {
+	DeployIds []*int  `json:"deploy_ids,omitempty"`
+	Messages  *string `json:"messages,omitempty"`
+}
+
+// GenericIntegrationPayload model for the payload of the generic integration
+type GenericIntegrationPayload struct {
+	Deploy *GenericIntegrationDeploy `json:"deploy,omitempty"`
+}
+
+// GenericIntegrationDeploy model for the build reported to the generic integration
+type GenericIntegrationDeploy struct {
+	Branch *string                   `json:"branch,omitempty"`
+	Commit *GenericIntegrationCommit `json:"commit,omitempty"`
+}
+
+// GenericIntegrationCommit model for the commit of a generic integration build
+type GenericIntegrationCommit struct {
+	SHA     *string `json:"sha,omitempty"`
+	Message *string `json:"message,omitempty"`
+}
+
+// List returns the incoming webhooks of a project
+func (service *WebhookService) List(projectID int) ([]*Webhook, *Call, error) {
+	path := fmt.Sprintf("/projects/%d/webhooks.json", projectID)
+	method := "GET"
+
+	call, err := service.s.NewCall(method, path, nil, nil, nil)
+	if err != nil {
+		return nil, call, err
+	}
+
+	type response struct {
+		Webhooks []*Webhook `json:"webhooks,omitempty"`
+	}
+	var res response
+	err = call.Do(&res)
+	if err != nil {
+		return nil, call, err
+	}
+
+	return res.Webhooks, call, nil
+}
+
+// Create creates a new incoming webhook for a stage of a project
+func (service *WebhookService) Create(projectID int, webhook *Webhook) (*Webhook, *Call, error) {
+	bytesArray, _ := json.Marshal(webhook)
+
+	path := fmt.Sprintf("/projects/%d/webhooks.json", projectID)
+	method := "POST"
+
+	call, err := service.s.NewCall(method, path, nil, nil, bytes.NewReader(bytesArray))
+	if err != nil {
+		return nil, call, err
+	}
+
+	err = call.Do(&webhook)
+	if err != nil {
+		return nil, call, err
+	}
+
+	return webhook, call, nil
+}
+
+// Delete deletes a sinlge incoming webhook of a project
+func (service *WebhookService) Delete(projectID int, id int) (*Call, error) {
+	path := fmt.Sprintf("/projects/%d/webhooks/%d.json", projectID, id)
+	method := "DELETE"
+
+	call, err := service.s.NewCall(method, path, nil, nil, nil)
+	if err != nil {
+		return call, err
+	}
+
+	return call, call.Do(nil)
+}
+
+// Trigger calls the integration endpoint of a source the way the CI provider does,
+// authenticated by the project token instead of the client token
+// The payload and headers have to match what the source sends, e.g. the
+// X-Github-Event header for github
+func (service *WebhookService) Trigger(source, projectToken string, payload interface{}, headers map[string]string) (*IntegrationResponse, *Call, error) {
+	bytesArray, err := json.Marshal(payload)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	path := fmt.Sprintf("/integrations/%s/%s", source, projectToken)
+	method := "POST"
+
+	call, err := service.s.NewCall(method, path, nil, nil, bytes.NewReader(bytesArray))
+	if err != nil {
+		return nil, call, err
+	}
+
+	// integrations are not authenticated by the api token and the
+	// source headers take precedence over the client headers
+	call.req.Header.Del("Authorization")
+	for key, value := range headers {
+		call.req.Header.Set(key, value)
+	}
+
+	var res IntegrationResponse
+	err = call.Do(&res)
+	if err != nil {
+		return nil, call, err
+	}
+
+	return &res, call, nil
+}
+
+// TriggerGeneric reports a successful build of a commit to the generic integration
+func (service *WebhookService) TriggerGeneric(projectToken, branch, sha, message string) (*IntegrationResponse, *Call, error) {
+	payload := &GenericIntegrationPayload{
+		Deploy: &GenericIntegrationDeploy{
+			Branch: String(branch),
+			Commit: &GenericIntegrationCommit{
+				SHA:     String(sha),
+				Message: String(message),
+			},
+		},
+	}
+
+	return service.Trigger(WebhookSourceGeneric, projectToken, payload, nil)
+}
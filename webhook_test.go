@@ -0,0 +1,243 @@
+package samson
+
+import (
+	"encoding/json"
+	"fmt"
+	"log"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func ExampleWebhookService_Create() {
+	client := New("token")
+
+	webhook := &Webhook{
+		StageID: Int(1),
+		Branch:  String("master"),
+		Source:  String(WebhookSourceTravis),
+	}
+
+	webhook, _, err := client.Webhooks.Create(2, webhook)
+	if err != nil {
+		log.Fatal(err)
+	}
+}
+
+func ExampleWebhookService_TriggerGeneric() {
+	client := New("token")
+
+	project, _, err := client.Projects.Get(2)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	res, _, err := client.Webhooks.TriggerGeneric(*project.Token, "master", "a4d6e3b7b9e0c1f2d3a4b5c6d7e8f9a0b1c2d3e4", "Bump rails")
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	fmt.Println(*res.Messages)
+}
+
+func TestWebhookServiceList(t *testing.T) {
+	var err error
+	assert := assert.New(t)
+
+	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal("GET", r.Method)
+		assert.Equal("/projects/2/webhooks.json", r.URL.Path)
+		checkHeaders(r, assert)
+
+		w.WriteHeader(200)
+		fmt.Fprintln(w, readTestData("webhooks.json"))
+	})
+
+	server := httptest.NewServer(handler)
+	defer server.Close()
+
+	client = New(token)
+	client.BaseURL = server.URL
+
+	webhooks, call, err := client.Webhooks.List(2)
+	assert.Nil(err)
+	assert.IsType(&Call{}, call)
+	assert.Equal(2, len(webhooks))
+	assert.Equal(WebhookSourceAnyCI, *webhooks[1].Source)
+}
+
+func TestWebhookServiceList_fail(t *testing.T) {
+	var err error
+	assert := assert.New(t)
+
+	client = New(token)
+	client.BaseURL = "^http://localhost"
+
+	webhooks, call, err := client.Webhooks.List(2)
+	assert.NotNil(err)
+	assert.IsType(&Call{}, call)
+	assert.Nil(webhooks)
+}
+
+func TestWebhookServiceCreate(t *testing.T) {
+	var err error
+	assert := assert.New(t)
+
+	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal("POST", r.Method)
+		assert.Equal("/projects/2/webhooks.json", r.URL.Path)
+		checkHeaders(r, assert)
+
+		var payload map[string]interface{}
+		err := json.NewDecoder(r.Body).Decode(&payload)
+		assert.Nil(err)
+		assert.Equal(float64(1), payload["stage_id"])
+		assert.Equal("master", payload["branch"])
+		assert.Equal("travis", payload["source"])
+
+		w.WriteHeader(200)
+		fmt.Fprintln(w, readTestData("webhook.json"))
+	})
+
+	server := httptest.NewServer(handler)
+	defer server.Close()
+
+	client = New(token)
+	client.BaseURL = server.URL
+
+	webhook := &Webhook{
+		StageID: Int(1),
+		Branch:  String("master"),
+		Source:  String(WebhookSourceTravis),
+	}
+
+	webhook, call, err := client.Webhooks.Create(2, webhook)
+	assert.Nil(err)
+	assert.IsType(&Call{}, call)
+	assert.Equal(1, *webhook.ID)
+}
+
+func TestWebhookServiceCreate_fail(t *testing.T) {
+	var err error
+	assert := assert.New(t)
+
+	client = New(token)
+	client.BaseURL = "^http://localhost"
+
+	webhook, call, err := client.Webhooks.Create(2, &Webhook{})
+	assert.NotNil(err)
+	assert.IsType(&Call{}, call)
+	assert.Nil(webhook)
+}
+
+func TestWebhookServiceDelete(t *testing.T) {
+	var err error
+	assert := assert.New(t)
+
+	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal("DELETE", r.Method)
+		assert.Equal("/projects/2/webhooks/1.json", r.URL.Path)
+		checkHeaders(r, assert)
+
+		w.WriteHeader(200)
+		fmt.Fprintln(w, "")
+	})
+
+	server := httptest.NewServer(handler)
+	defer server.Close()
+
+	client = New(token)
+	client.BaseURL = server.URL
+
+	call, err := client.Webhooks.Delete(2, 1)
+	assert.Nil(err)
+	assert.IsType(&Call{}, call)
+}
+
+func TestWebhookServiceTrigger(t *testing.T) {
+	var err error
+	assert := assert.New(t)
+
+	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal("POST", r.Method)
+		assert.Equal("/integrations/github/projecttoken", r.URL.Path)
+		assert.Equal("", r.Header.Get("Authorization"))
+		assert.Equal("push", r.Header.Get("X-Github-Event"))
+
+		var payload map[string]interface{}
+		err := json.NewDecoder(r.Body).Decode(&payload)
+		assert.Nil(err)
+		assert.Equal("refs/heads/master", payload["ref"])
+
+		w.WriteHeader(200)
+		fmt.Fprintln(w, readTestData("integration.json"))
+	})
+
+	server := httptest.NewServer(handler)
+	defer server.Close()
+
+	client = New(token)
+	client.BaseURL = server.URL
+
+	headers := map[string]string{"X-Github-Event": "push"}
+	payload := map[string]string{"ref": "refs/heads/master"}
+
+	res, call, err := client.Webhooks.Trigger(WebhookSourceGithub, "projecttoken", payload, headers)
+	assert.Nil(err)
+	assert.IsType(&Call{}, call)
+	assert.Equal(7, *res.DeployIds[0])
+	assert.Equal(map[string]string{"X-Github-Event": "push"}, headers)
+}
+
+func TestWebhookServiceTrigger_fail(t *testing.T) {
+	var err error
+	assert := assert.New(t)
+
+	client = New(token)
+
+	res, call, err := client.Webhooks.Trigger(WebhookSourceGithub, "projecttoken", make(chan int), nil)
+	assert.NotNil(err)
+	assert.Nil(call)
+	assert.Nil(res)
+
+	client.BaseURL = "^http://localhost"
+
+	res, call, err = client.Webhooks.Trigger(WebhookSourceGithub, "projecttoken", nil, nil)
+	assert.NotNil(err)
+	assert.IsType(&Call{}, call)
+	assert.Nil(res)
+}
+
+func TestWebhookServiceTriggerGeneric(t *testing.T) {
+	var err error
+	assert := assert.New(t)
+
+	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal("POST", r.Method)
+		assert.Equal("/integrations/generic/projecttoken", r.URL.Path)
+		assert.Equal("application/json", r.Header.Get("Content-Type"))
+
+		var payload GenericIntegrationPayload
+		err := json.NewDecoder(r.Body).Decode(&payload)
+		assert.Nil(err)
+		assert.Equal("master", *payload.Deploy.Branch)
+		assert.Equal("a4d6e3b7b9e0c1f2d3a4b5c6d7e8f9a0b1c2d3e4", *payload.Deploy.Commit.SHA)
+		assert.Equal("Bump rails", *payload.Deploy.Commit.Message)
+
+		w.WriteHeader(200)
+		fmt.Fprintln(w, readTestData("integration.json"))
+	})
+
+	server := httptest.NewServer(handler)
+	defer server.Close()
+
+	client = New(token)
+	client.BaseURL = server.URL
+
+	res, call, err := client.Webhooks.TriggerGeneric("projecttoken", "master", "a4d6e3b7b9e0c1f2d3a4b5c6d7e8f9a0b1c2d3e4", "Bump rails")
+	assert.Nil(err)
+	assert.IsType(&Call{}, call)
+	assert.Equal(1, len(res.DeployIds))
+}